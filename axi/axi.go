@@ -2,10 +2,16 @@ package axi
 
 import (
 	"fmt"
+	"html"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/text"
+
 	svg "github.com/ajstarks/svgo"
 )
 
@@ -22,6 +28,7 @@ type Axi struct {
 		X float64
 		Y float64
 	}
+	metadata [][2]string
 }
 
 type Drawer interface {
@@ -52,10 +59,16 @@ func NewAxi(width, height float64) *Axi {
 }
 
 func (axi *Axi) Done() {
-	ctx := svg.New(os.Stdout)
+	axi.write(os.Stdout)
+}
+
+// write renders the drawing as an SVG to w
+func (axi *Axi) write(w io.Writer) {
+	ctx := svg.New(w)
 	ctx.Start(int(axi.Width), int(axi.Height), "xmlns:inkscape=\"http://www.inkscape.org/namespaces/inkscape\"")
 
 	axi.ctx = ctx
+	axi.writeMetadata()
 
 	// Iterate over layers and render the items they contain
 	for _, layer := range axi.layers {
@@ -79,6 +92,35 @@ func (axi *Axi) Done() {
 	axi.ctx.End()
 }
 
+// metadataName matches the names that can be written as data attributes
+var metadataName = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// Metadata records a value in the metadata of the SVG written by Done. It is written as a
+// data-name attribute, so name must only contain letters, digits and hyphens. It panics if name
+// has any other characters.
+func (axi *Axi) Metadata(name, value string) {
+	if !metadataName.MatchString(name) {
+		panic(fmt.Sprintf("axi: invalid metadata name %q", name))
+	}
+	axi.metadata = append(axi.metadata, [2]string{name, value})
+}
+
+// RecordSeed records the seed the drawing was generated from so it can be made again
+func (axi *Axi) RecordSeed(seed int64) {
+	axi.Metadata("seed", strconv.FormatInt(seed, 10))
+}
+
+func (axi *Axi) writeMetadata() {
+	if len(axi.metadata) == 0 {
+		return
+	}
+	attrs := make([]string, len(axi.metadata))
+	for i, m := range axi.metadata {
+		attrs[i] = fmt.Sprintf("data-%s=\"%s\"", m[0], html.EscapeString(m[1]))
+	}
+	fmt.Fprintf(axi.ctx.Writer, "<metadata %s></metadata>\n", strings.Join(attrs, " "))
+}
+
 func NewAxiWithWriter(w io.Writer, width, height float64) *Axi {
 	s := svg.New(w)
 	s.Start(int(width), int(height), "xmlns:inkscape=\"http://www.inkscape.org/namespaces/inkscape\"")
@@ -155,6 +197,16 @@ func (axi *Axi) Paths(p []PathData) {
 	}
 }
 
+// Text draws the text in a single stroke font with the baseline of the first line at y, and
+// returns its strokes
+func (axi *Axi) Text(x, y float64, s string, opts text.Options) []*path.Path {
+	paths := text.Paths(x, y, s, opts)
+	for _, p := range paths {
+		axi.Path(p)
+	}
+	return paths
+}
+
 func (axi *Axi) MoveTo(x, y float64) {
 	axi.position.X = x
 	axi.position.Y = y
@@ -0,0 +1,21 @@
+package axi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata(t *testing.T) {
+	axi := NewAxi(100, 100)
+	axi.RecordSeed(42)
+	axi.Metadata("title", "Lines & \"curves\"")
+	var buf bytes.Buffer
+	axi.write(&buf)
+	assert.Contains(t, buf.String(), `<metadata data-seed="42" data-title="Lines &amp; &#34;curves&#34;"></metadata>`)
+
+	for _, name := range []string{"", "two words", `a"b`, "a>b", "a=b"} {
+		assert.Panics(t, func() { axi.Metadata(name, "x") }, name)
+	}
+}
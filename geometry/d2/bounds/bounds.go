@@ -101,6 +101,12 @@ func (b Bounds) Overlaps(bounds Bounds) bool {
 	return Overlap(b, bounds)
 }
 
+// OverlapsInclusive returns true if the bounds overlap or touch. Unlike Overlaps, bounds that
+// only share an edge or a corner count.
+func (b Bounds) OverlapsInclusive(bounds Bounds) bool {
+	return b.Left <= bounds.Right && bounds.Left <= b.Right && b.Top <= bounds.Bottom && bounds.Top <= b.Bottom
+}
+
 func (b Bounds) Bounds() Bounds {
 	return b
 }
@@ -218,6 +218,26 @@ func TestOverlaps(t *testing.T) {
 		assert.False(t, b2.Overlaps(b1))
 	})
 
+	t.Run("Overlaps inclusive", func(t *testing.T) {
+		b1 := Bounds{
+			Top:    0,
+			Right:  1,
+			Bottom: 1,
+			Left:   0,
+		}
+		edge := NewBounds(0, 2, 1, 1)
+		corner := NewBounds(1, 2, 2, 1)
+		apart := NewBounds(0, 2, 1, 1.5)
+		inside := NewBounds(0.25, 0.75, 0.75, 0.25)
+		assert.True(t, b1.OverlapsInclusive(edge))
+		assert.True(t, edge.OverlapsInclusive(b1))
+		assert.True(t, b1.OverlapsInclusive(corner))
+		assert.True(t, b1.OverlapsInclusive(inside))
+		assert.True(t, inside.OverlapsInclusive(b1))
+		assert.False(t, b1.OverlapsInclusive(apart))
+		assert.False(t, apart.OverlapsInclusive(b1))
+	})
+
 	t.Run("Overlaps on TopLeft/BottomRight corner", func(t *testing.T) {
 		b1 := Bounds{
 			Top:    0,
@@ -0,0 +1,75 @@
+package circle
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// kappa is the distance of the control points of a cubic bezier quarter circle from its ends,
+// as a fraction of the radius
+const kappa = 0.5522847498307936
+
+type Circle struct {
+	Center point.Point
+	Radius float64
+}
+
+func NewCircle(x, y, r float64) Circle {
+	return Circle{
+		Center: point.NewPoint(x, y),
+		Radius: r,
+	}
+}
+
+// Contains returns true if x, y is inside or on the circle
+func (c Circle) Contains(x, y float64) bool {
+	return c.Center.Distance(point.NewPoint(x, y)) <= c.Radius
+}
+
+func (c Circle) Bounds() bounds.Bounds {
+	return bounds.NewBounds(c.Center.Y-c.Radius, c.Center.X+c.Radius, c.Center.Y+c.Radius, c.Center.X-c.Radius)
+}
+
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Path returns a closed path made of four cubic beziers that approximates the circle
+func (c Circle) Path() *path.Path {
+	segments := make([]path.Segment, 4)
+	for i := range segments {
+		angle := float64(i) * math.Pi / 2
+		from := c.Center.AddPoint(point.NewPointFromAngle(angle, c.Radius))
+		to := c.Center.AddPoint(point.NewPointFromAngle(angle+math.Pi/2, c.Radius))
+		c1 := from.AddPoint(point.NewPointFromAngle(angle+math.Pi/2, c.Radius*kappa))
+		c2 := to.AddPoint(point.NewPointFromAngle(angle, c.Radius*kappa))
+		segments[i] = path.NewCubicBezierSegment(from, c1, c2)
+	}
+	return path.FromSegments(segments, true)
+}
+
+// Through returns the smallest circle that passes through both points
+func Through(a, b point.Point) Circle {
+	return Circle{
+		Center: a.AddPoint(b).ScalarMult(0.5),
+		Radius: a.Distance(b) / 2,
+	}
+}
+
+// Circumcircle returns the circle that passes through all three points. ok is false if the
+// points are on a line.
+func Circumcircle(a, b, c point.Point) (circle Circle, ok bool) {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		return Circle{}, false
+	}
+	b2 := bx*bx + by*by
+	c2 := cx*cx + cy*cy
+	center := point.NewPoint(a.X+(cy*b2-by*c2)/d, a.Y+(bx*c2-cx*b2)/d)
+	return Circle{Center: center, Radius: center.Distance(a)}, true
+}
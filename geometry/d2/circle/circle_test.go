@@ -0,0 +1,32 @@
+package circle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircle(t *testing.T) {
+	c := NewCircle(5, 5, 2)
+	assert.True(t, c.Contains(6, 6))
+	assert.False(t, c.Contains(7, 7))
+	assert.Equal(t, 4*math.Pi, c.Area())
+	b := c.Bounds()
+	assert.Equal(t, 3., b.Top)
+	assert.Equal(t, 7., b.Right)
+
+	p := c.Path()
+	assert.True(t, p.Closed)
+	for i := 0.; i < 4; i += 0.1 {
+		x, y := p.Interpolate(i)
+		assert.InDelta(t, 2, point.NewPoint(x, y).Distance(c.Center), 0.001)
+	}
+
+	cc, ok := Circumcircle(point.NewPoint(0, 0), point.NewPoint(4, 0), point.NewPoint(0, 4))
+	assert.True(t, ok)
+	assert.Equal(t, point.NewPoint(2, 2), cc.Center)
+	_, ok = Circumcircle(point.NewPoint(0, 0), point.NewPoint(1, 1), point.NewPoint(2, 2))
+	assert.False(t, ok)
+}
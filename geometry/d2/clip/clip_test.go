@@ -0,0 +1,119 @@
+package clip
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClipPath(t *testing.T) {
+	b := bounds.NewBounds(0, 10, 10, 0)
+
+	t.Run("line crossing twice", func(t *testing.T) {
+		paths := ClipPath(b, path.NewOpenPath([]float64{-5, 5, 15, 5}))
+		assert.Equal(t, 1, len(paths))
+		assert.Equal(t, []point.Point{{X: 0, Y: 5}, {X: 10, Y: 5}}, paths[0].Points())
+	})
+
+	t.Run("leaves and reenters", func(t *testing.T) {
+		paths := ClipPath(b, path.NewOpenPath([]float64{5, 5, 5, 15, 8, 15, 8, 5}))
+		assert.Equal(t, 2, len(paths))
+		assert.Equal(t, []point.Point{{X: 5, Y: 5}, {X: 5, Y: 10}}, paths[0].Points())
+		assert.Equal(t, []point.Point{{X: 8, Y: 10}, {X: 8, Y: 5}}, paths[1].Points())
+	})
+
+	t.Run("curve crossing twice", func(t *testing.T) {
+		curve := path.FromSegments([]path.Segment{
+			path.NewCubicBezierSegment(point.NewPoint(2, 5), point.NewPoint(2, -5), point.NewPoint(8, -5)),
+			path.NewSegment(8, 5),
+		}, false)
+		paths := ClipPath(b, curve)
+		assert.Equal(t, 2, len(paths))
+		for _, p := range paths {
+			assert.NotNil(t, p.Segments[0].Curve)
+			end := p.Segments[len(p.Segments)-1]
+			assert.True(t, b.ContainsInclusive(&end.Point))
+		}
+		assert.InDelta(t, 0, paths[0].Segments[1].Y, 1e-9)
+		assert.InDelta(t, 0, paths[1].Segments[0].Y, 1e-9)
+	})
+
+	t.Run("outside", func(t *testing.T) {
+		assert.Equal(t, 0, len(ClipPath(b, path.NewOpenPath([]float64{20, 20, 30, 30}))))
+	})
+}
+
+func TestClipPathToPolygon(t *testing.T) {
+	square := polygon.NewRectangle(0, 0, 10, 10).Polygon
+	hole := polygon.NewRectangle(4, 4, 2, 2).Polygon
+	lne := path.NewOpenPath([]float64{-5, 5, 15, 5})
+
+	paths := ClipPathToPolygon(square, lne, false, hole)
+	assert.Equal(t, 2, len(paths))
+	assert.Equal(t, []point.Point{{X: 0, Y: 5}, {X: 4, Y: 5}}, paths[0].Points())
+	assert.Equal(t, []point.Point{{X: 6, Y: 5}, {X: 10, Y: 5}}, paths[1].Points())
+
+	paths = ClipPathToPolygon(square, lne, true, hole)
+	assert.Equal(t, 3, len(paths))
+	assert.Equal(t, []point.Point{{X: 4, Y: 5}, {X: 6, Y: 5}}, paths[1].Points())
+
+	t.Run("closed path", func(t *testing.T) {
+		diamond := path.NewClosedPath([]float64{10, 5, 5, 15, 0, 5, 5, -5})
+		paths := ClipPathToPolygon(square, diamond, true)
+		assert.Equal(t, 2, len(paths))
+
+		// The parts inside join across the start of the path
+		paths = ClipPathToPolygon(square, diamond, false)
+		assert.Equal(t, 2, len(paths))
+		assert.Equal(t, []point.Point{{X: 7.5, Y: 0}, {X: 10, Y: 5}, {X: 7.5, Y: 10}}, paths[0].Points())
+
+		inside := path.NewClosedPath([]float64{1, 1, 2, 1, 2, 2})
+		paths = ClipPathToPolygon(square, inside, false)
+		assert.Equal(t, 1, len(paths))
+		assert.True(t, paths[0].Closed)
+	})
+
+	t.Run("arc", func(t *testing.T) {
+		// A semicircle of radius 50 over the center 50, 0
+		semicircle := path.FromSegments([]path.Segment{
+			{Point: point.NewPoint(0, 0), Curve: path.NewArc(50, 50, 0, false, true)},
+			path.NewSegment(100, 0),
+		}, false)
+		band := polygon.NewRectangle(40, -100, 20, 200).Polygon
+		paths := ClipPathToPolygon(band, semicircle, false)
+		assert.Equal(t, 1, len(paths))
+		pts := paths[0].Points()
+		assert.Equal(t, 2, len(pts))
+		assert.InDelta(t, 40, pts[0].X, 1e-6)
+		assert.InDelta(t, -math.Sqrt(2400), pts[0].Y, 1e-6)
+		assert.InDelta(t, 60, pts[1].X, 1e-6)
+		assert.InDelta(t, -math.Sqrt(2400), pts[1].Y, 1e-6)
+		// The part kept is still the arc
+		assert.NotNil(t, paths[0].Segments[0].Curve.Arc)
+		x, y := paths[0].Interpolate(0.5)
+		assert.InDelta(t, 50, x, 1e-6)
+		assert.InDelta(t, -50, y, 1e-6)
+
+		// The arc misses a polygon that only crosses the line between its ends
+		chord := polygon.NewRectangle(40, -10, 20, 20).Polygon
+		assert.Empty(t, ClipPathToPolygon(chord, semicircle, false))
+		paths = ClipPathToPolygon(chord, semicircle, true)
+		assert.Equal(t, 1, len(paths))
+		assert.Equal(t, semicircle.Segments, paths[0].Segments)
+	})
+}
+
+func TestClipPathToMultiPolygon(t *testing.T) {
+	m := polygon.MultiPolygon{
+		polygon.NewPolygonWithHoles(polygon.NewRectangle(0, 0, 10, 10).Polygon, polygon.NewRectangle(4, 4, 2, 2).Polygon),
+		polygon.NewPolygonWithHoles(polygon.NewRectangle(20, 0, 10, 10).Polygon),
+	}
+	paths := ClipPathToMultiPolygon(m, path.NewOpenPath([]float64{-5, 5, 35, 5}), false)
+	assert.Equal(t, 3, len(paths))
+	assert.Equal(t, []point.Point{{X: 20, Y: 5}, {X: 30, Y: 5}}, paths[2].Points())
+}
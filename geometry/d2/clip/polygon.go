@@ -0,0 +1,285 @@
+package clip
+
+import (
+	"math"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bezier"
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+// A piece is a single line, cubic bezier or arc of the path being clipped. Lines and arcs keep
+// their end points in p[0] and p[3], and arc holds the curve of an arc.
+type piece struct {
+	p     [4]point.Point
+	cubic bool
+	arc   *path.Curve
+}
+
+func (pc piece) at(t float64) point.Point {
+	if pc.arc != nil {
+		return point.NewPoint(pc.arc.Interpolate(pc.p[0], pc.p[3], t))
+	}
+	if !pc.cubic {
+		return pc.p[0].AddPoint(pc.p[3].SubtractPoint(pc.p[0]).ScalarMult(t))
+	}
+	return point.NewPoint(bezier.Polynomial(pc.p[0], pc.p[1], pc.p[2], pc.p[3], t))
+}
+
+// between returns the part of the piece from t0 to t1
+func (pc piece) between(t0, t1 float64) piece {
+	if t0 == 0 && t1 == 1 {
+		return pc
+	}
+	if pc.arc != nil {
+		a, b := pc.at(t0), pc.at(t1)
+		return piece{p: [4]point.Point{a, a, b, b}, arc: pc.arc.Arc.Section(pc.p[0], pc.p[3], t0, t1)}
+	}
+	if !pc.cubic {
+		return piece{p: [4]point.Point{pc.at(t0), pc.at(t0), pc.at(t1), pc.at(t1)}}
+	}
+	b := pc.p[:]
+	if t0 > 0 {
+		_, b = path.Subdivide(b, t0)
+	}
+	if t1 < 1 {
+		b, _ = path.Subdivide(b, (t1-t0)/(1-t0))
+	}
+	return piece{p: [4]point.Point{b[0], b[1], b[2], b[3]}, cubic: true}
+}
+
+func (pc piece) segment() path.Segment {
+	if pc.cubic {
+		return path.NewCubicBezierSegment(pc.p[0], pc.p[1], pc.p[2])
+	}
+	return path.Segment{Point: pc.p[0], Curve: pc.arc}
+}
+
+// ClipPathToPolygon returns the parts of the path that are inside the polygon and outside of all
+// of the holes. If invert is true the parts outside of the polygon or inside a hole are returned
+// instead. Curves are cut where they cross the boundary, with beziers kept as cubics and arcs as
+// arcs.
+func ClipPathToPolygon(poly *polygon.Polygon, pth *path.Path, invert bool, holes ...*polygon.Polygon) []*path.Path {
+	rings := make([][]point.Point, 0, len(holes)+1)
+	rings = append(rings, poly.Points())
+	for _, hole := range holes {
+		rings = append(rings, hole.Points())
+	}
+	return ClipPathToRings(rings, pth, invert)
+}
+
+// ClipPathToMultiPolygon returns the parts of the path that are inside the multipolygon, or
+// outside of it if invert is true.
+func ClipPathToMultiPolygon(m polygon.MultiPolygon, pth *path.Path, invert bool) []*path.Path {
+	return ClipPathToRings(m.Rings(), pth, invert)
+}
+
+// ClipPathToRings returns the parts of the path inside the area enclosed by the rings using the
+// even-odd rule, or outside of it if invert is true. Parts of the path that run along the
+// boundary count as inside.
+func ClipPathToRings(rings [][]point.Point, pth *path.Path, invert bool) []*path.Path {
+	if len(pth.Segments) == 0 {
+		return nil
+	}
+	scale := 0.
+	for _, ring := range rings {
+		for _, p := range ring {
+			scale = math.Max(scale, math.Max(math.Abs(p.X), math.Abs(p.Y)))
+		}
+	}
+	eps := 1e-9 * (1 + scale)
+	keep := func(p point.Point) bool {
+		return isInside(rings, p, eps) != invert
+	}
+
+	if len(pth.Segments) == 1 {
+		if keep(pth.Segments[0].Point) {
+			return []*path.Path{path.FromSegments(append([]path.Segment{}, pth.Segments...), false)}
+		}
+		return nil
+	}
+
+	var runs [][]piece
+	var run []piece
+	// Whether the first and last parts of the path were kept, for joining the ends of closed paths
+	first, last := false, false
+	all := true
+	for i, pc := range pieces(pth) {
+		ts := intersections(pc, rings)
+		for j := 0; j < len(ts)-1; j++ {
+			t0, t1 := ts[j], ts[j+1]
+			kept := keep(pc.at((t0 + t1) / 2))
+			if i == 0 && j == 0 {
+				first = kept
+			}
+			last = kept
+			if !kept {
+				all = false
+				if len(run) > 0 {
+					runs = append(runs, run)
+					run = nil
+				}
+				continue
+			}
+			run = append(run, pc.between(t0, t1))
+		}
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+
+	if all {
+		return []*path.Path{path.FromSegments(append([]path.Segment{}, pth.Segments...), pth.Closed)}
+	}
+	if pth.Closed && first && last && len(runs) > 1 {
+		runs[0] = append(runs[len(runs)-1], runs[0]...)
+		runs = runs[:len(runs)-1]
+	}
+
+	ret := make([]*path.Path, 0, len(runs))
+	for _, run := range runs {
+		segments := make([]path.Segment, 0, len(run)+1)
+		for _, pc := range run {
+			segments = append(segments, pc.segment())
+		}
+		end := run[len(run)-1].p[3]
+		segments = append(segments, path.NewSegment(end.X, end.Y))
+		ret = append(ret, path.FromSegments(segments, false))
+	}
+	return ret
+}
+
+// pieces splits the path into lines, cubics and arcs, skipping any of zero length. Quadratic
+// curves are converted to cubics.
+func pieces(pth *path.Path) []piece {
+	n := len(pth.Segments)
+	count := n - 1
+	if pth.Closed {
+		count = n
+	}
+	var ret []piece
+	for i := 0; i < count; i++ {
+		seg := pth.Segments[i]
+		to := pth.Segments[(i+1)%n].Point
+		if seg.Curve != nil {
+			if c1, c2, ok := seg.Curve.CubicControls(seg.Point, to); ok {
+				if !(seg.Point.Equals(to) && c1.Equals(to) && c2.Equals(to)) {
+					ret = append(ret, piece{p: [4]point.Point{seg.Point, c1, c2, to}, cubic: true})
+				}
+				continue
+			}
+		}
+		if !seg.Point.Equals(to) {
+			var arc *path.Curve
+			if seg.Curve != nil && seg.Curve.Arc != nil {
+				arc = seg.Curve
+			}
+			ret = append(ret, piece{p: [4]point.Point{seg.Point, seg.Point, to, to}, arc: arc})
+		}
+	}
+	return ret
+}
+
+// intersections returns the sorted parameters where the piece crosses the rings, starting with 0
+// and ending with 1.
+func intersections(pc piece, rings [][]point.Point) []float64 {
+	ts := []float64{0, 1}
+	flat := flatten(pc)
+	for _, ring := range rings {
+		for i := range ring {
+			c := ring[i]
+			d := ring[(i+1)%len(ring)]
+			if !pc.cubic && pc.arc == nil {
+				if t, _, ok := line.GetIntersectionParams(pc.p[0].X, pc.p[0].Y, pc.p[3].X, pc.p[3].Y, c.X, c.Y, d.X, d.Y); ok {
+					ts = append(ts, t)
+				}
+				continue
+			}
+			for j := 0; j < len(flat)-1; j++ {
+				a, b := pc.at(flat[j]), pc.at(flat[j+1])
+				if s, _, ok := line.GetIntersectionParams(a.X, a.Y, b.X, b.Y, c.X, c.Y, d.X, d.Y); ok {
+					ts = append(ts, refine(pc, c, d, flat[j], flat[j+1], s))
+				}
+			}
+		}
+	}
+	sort.Float64s(ts)
+	ret := ts[:1]
+	for _, t := range ts[1:] {
+		if t-ret[len(ret)-1] > 1e-9 {
+			ret = append(ret, t)
+		} else if t == 1 {
+			ret[len(ret)-1] = 1
+		}
+	}
+	return ret
+}
+
+// flatten returns parameters along the piece whose points are close to it when joined with
+// lines
+func flatten(pc piece) []float64 {
+	switch {
+	case pc.cubic:
+		size := pc.p[0].Distance(pc.p[1]) + pc.p[1].Distance(pc.p[2]) + pc.p[2].Distance(pc.p[3])
+		return path.FlattenCubicParams(pc.p[:], 1e-4*size)
+	case pc.arc != nil:
+		size := math.Max(pc.p[0].Distance(pc.p[3]), math.Max(math.Abs(pc.arc.Arc.Rx), math.Abs(pc.arc.Arc.Ry)))
+		return pc.arc.FlattenParams(pc.p[0], pc.p[3], 1e-4*size)
+	}
+	return []float64{0, 1}
+}
+
+// refine finds where the curve crosses the line through c and d between t0 and t1 by bisection.
+// s is the position of the crossing along the chord from t0 to t1, which is used if the curve
+// does not change sides between them.
+func refine(pc piece, c, d point.Point, t0, t1, s float64) float64 {
+	dir := d.SubtractPoint(c)
+	side := func(t float64) float64 {
+		v := pc.at(t).SubtractPoint(c)
+		return dir.X*v.Y - dir.Y*v.X
+	}
+	f0, f1 := side(t0), side(t1)
+	if f0 == 0 {
+		return t0
+	}
+	if f1 == 0 {
+		return t1
+	}
+	if (f0 > 0) == (f1 > 0) {
+		return t0 + s*(t1-t0)
+	}
+	for i := 0; i < 50; i++ {
+		mid := (t0 + t1) / 2
+		fm := side(mid)
+		if (fm > 0) == (f0 > 0) {
+			t0, f0 = mid, fm
+		} else {
+			t1 = mid
+		}
+	}
+	return (t0 + t1) / 2
+}
+
+// isInside returns true if p is inside the rings by the even-odd rule or within eps of their
+// boundary.
+func isInside(rings [][]point.Point, p point.Point, eps float64) bool {
+	inside := false
+	for _, ring := range rings {
+		for i := range ring {
+			a := ring[i]
+			b := ring[(i+1)%len(ring)]
+			if line.DistanceToPoint(a.X, a.Y, b.X, b.Y, p.X, p.Y) <= eps {
+				return true
+			}
+			if (a.Y <= p.Y && p.Y < b.Y) || (b.Y <= p.Y && p.Y < a.Y) {
+				if x := a.X + (p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y); x < p.X {
+					inside = !inside
+				}
+			}
+		}
+	}
+	return inside
+}
@@ -0,0 +1,223 @@
+package delaunay
+
+import (
+	"math"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+// A Triangulation is the Delaunay triangulation of a set of points. No point is inside the
+// circumcircle of any triangle.
+type Triangulation struct {
+	Points []point.Point
+	// Triangles are indices into Points. The vertices of each triangle turn clockwise on screen.
+	Triangles [][3]int
+}
+
+type triangle struct {
+	v      [3]int
+	center point.Point
+	r2     float64
+}
+
+// Triangulate returns the Delaunay triangulation of the points using the Bowyer-Watson
+// algorithm. Points are inserted from left to right so that triangles that can no longer
+// change are set aside. Repeated points are not included in any triangle. The result only
+// depends on the points, not their order.
+func Triangulate(points []point.Point) *Triangulation {
+	ret := &Triangulation{Points: points}
+	n := len(points)
+	if n < 3 {
+		return ret
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := points[order[i]], points[order[j]]
+		return a.X < b.X || (a.X == b.X && a.Y < b.Y)
+	})
+
+	// The super triangle contains all of the points. Its vertices come after the input points.
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX = math.Min(minX, p.X)
+		minY = math.Min(minY, p.Y)
+		maxX = math.Max(maxX, p.X)
+		maxY = math.Max(maxY, p.Y)
+	}
+	span := math.Max(math.Max(maxX-minX, maxY-minY), 1)
+	cx, cy := (minX+maxX)/2, (minY+maxY)/2
+	pts := append(append([]point.Point{}, points...),
+		point.NewPoint(cx-1e4*span, cy+1e4*span),
+		point.NewPoint(cx, cy-1e4*span),
+		point.NewPoint(cx+1e4*span, cy+1e4*span),
+	)
+
+	open := []triangle{newTriangle(pts, n, n+1, n+2)}
+	var closed []triangle
+	for k, i := range order {
+		p := pts[i]
+		if k > 0 && p.Equals(pts[order[k-1]]) {
+			continue
+		}
+
+		// Triangles whose circumcircle is entirely to the left of p can't contain any later point
+		keep := open[:0]
+		for _, t := range open {
+			if t.center.X+math.Sqrt(t.r2) < p.X {
+				closed = append(closed, t)
+			} else {
+				keep = append(keep, t)
+			}
+		}
+		open = keep
+
+		// Remove the triangles whose circumcircle contains p and fill the hole with triangles that
+		// fan out from p
+		edges := map[[2]int]int{}
+		keep = open[:0]
+		var cavity [][2]int
+		for _, t := range open {
+			if dx, dy := p.X-t.center.X, p.Y-t.center.Y; dx*dx+dy*dy < t.r2 {
+				for e := 0; e < 3; e++ {
+					edge := [2]int{t.v[e], t.v[(e+1)%3]}
+					edges[edge]++
+					cavity = append(cavity, edge)
+				}
+			} else {
+				keep = append(keep, t)
+			}
+		}
+		open = keep
+		for _, edge := range cavity {
+			// Edges shared by two removed triangles are inside the hole
+			if edges[[2]int{edge[1], edge[0]}] > 0 {
+				continue
+			}
+			open = append(open, newTriangle(pts, edge[0], edge[1], i))
+		}
+	}
+	closed = append(closed, open...)
+
+	for _, t := range closed {
+		if t.v[0] >= n || t.v[1] >= n || t.v[2] >= n {
+			continue
+		}
+		ret.Triangles = append(ret.Triangles, normalize(t.v))
+	}
+	sort.Slice(ret.Triangles, func(i, j int) bool {
+		a, b := ret.Triangles[i], ret.Triangles[j]
+		return a[0] < b[0] || (a[0] == b[0] && (a[1] < b[1] || (a[1] == b[1] && a[2] < b[2])))
+	})
+	return ret
+}
+
+func newTriangle(pts []point.Point, a, b, c int) triangle {
+	pa, pb, pc := pts[a], pts[b], pts[c]
+	if cross(pa, pb, pc) < 0 {
+		b, c = c, b
+		pb, pc = pc, pb
+	}
+	bx, by := pb.X-pa.X, pb.Y-pa.Y
+	cx, cy := pc.X-pa.X, pc.Y-pa.Y
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		// Collinear points have no circumcircle. Treat it as infinitely large so the triangle is
+		// replaced by the next point.
+		return triangle{v: [3]int{a, b, c}, center: pa, r2: math.Inf(1)}
+	}
+	b2 := bx*bx + by*by
+	c2 := cx*cx + cy*cy
+	ux := (cy*b2 - by*c2) / d
+	uy := (bx*c2 - cx*b2) / d
+	return triangle{
+		v:      [3]int{a, b, c},
+		center: point.NewPoint(pa.X+ux, pa.Y+uy),
+		r2:     ux*ux + uy*uy,
+	}
+}
+
+// normalize rotates the vertices so the smallest index is first, keeping their order
+func normalize(v [3]int) [3]int {
+	for v[0] > v[1] || v[0] > v[2] {
+		v[0], v[1], v[2] = v[1], v[2], v[0]
+	}
+	return v
+}
+
+func cross(a, b, c point.Point) float64 {
+	return (b.X-a.X)*(c.Y-b.Y) - (b.Y-a.Y)*(c.X-b.X)
+}
+
+// Edges returns each edge of the triangulation once, with the smaller index first
+func (t *Triangulation) Edges() [][2]int {
+	seen := map[[2]int]bool{}
+	var ret [][2]int
+	for _, tri := range t.Triangles {
+		for e := 0; e < 3; e++ {
+			a, b := tri[e], tri[(e+1)%3]
+			if a > b {
+				a, b = b, a
+			}
+			if !seen[[2]int{a, b}] {
+				seen[[2]int{a, b}] = true
+				ret = append(ret, [2]int{a, b})
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i][0] < ret[j][0] || (ret[i][0] == ret[j][0] && ret[i][1] < ret[j][1])
+	})
+	return ret
+}
+
+// Neighbors returns the sorted indices of the points joined to each point by an edge.
+// When all of the points are on a line each point's neighbours are the points next to it.
+func (t *Triangulation) Neighbors() [][]int {
+	ret := make([][]int, len(t.Points))
+	if len(t.Triangles) == 0 {
+		order := make([]int, 0, len(t.Points))
+		for i := range t.Points {
+			order = append(order, i)
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := t.Points[order[i]], t.Points[order[j]]
+			return a.X < b.X || (a.X == b.X && a.Y < b.Y)
+		})
+		prev := -1
+		for _, i := range order {
+			if prev >= 0 && t.Points[prev].Equals(t.Points[i]) {
+				continue
+			}
+			if prev >= 0 {
+				ret[prev] = append(ret[prev], i)
+				ret[i] = append(ret[i], prev)
+			}
+			prev = i
+		}
+	} else {
+		for _, e := range t.Edges() {
+			ret[e[0]] = append(ret[e[0]], e[1])
+			ret[e[1]] = append(ret[e[1]], e[0])
+		}
+	}
+	for _, n := range ret {
+		sort.Ints(n)
+	}
+	return ret
+}
+
+// Polygons returns each triangle as a polygon
+func (t *Triangulation) Polygons() []*polygon.Polygon {
+	ret := make([]*polygon.Polygon, len(t.Triangles))
+	for i, tri := range t.Triangles {
+		ret[i] = polygon.NewPolygon(polygon.Points{t.Points[tri[0]], t.Points[tri[1]], t.Points[tri[2]]})
+	}
+	return ret
+}
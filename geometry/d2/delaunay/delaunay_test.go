@@ -0,0 +1,164 @@
+package delaunay
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomPoints(n int) []point.Point {
+	rng := rand.New(rand.NewSource(42))
+	points := make([]point.Point, n)
+	for i := range points {
+		points[i] = point.NewPoint(rng.Float64()*100, rng.Float64()*100)
+	}
+	return points
+}
+
+// assertDelaunay checks that no point is inside the circumcircle of a triangle and that the
+// triangles cover the convex hull
+func assertDelaunay(t *testing.T, tri *Triangulation) {
+	area := 0.
+	for _, v := range tri.Triangles {
+		tr := newTriangle(tri.Points, v[0], v[1], v[2])
+		for _, p := range tri.Points {
+			d := p.SubtractPoint(tr.center)
+			assert.False(t, d.Dot(d) < tr.r2*(1-1e-9))
+		}
+		area += polygon.NewPolygon(polygon.Points{tri.Points[v[0]], tri.Points[v[1]], tri.Points[v[2]]}).SignedArea()
+	}
+	assert.InDelta(t, polygon.ConvexHull(tri.Points).Area(), area, 1e-6)
+}
+
+func TestTriangulate(t *testing.T) {
+	t.Run("random points", func(t *testing.T) {
+		points := randomPoints(300)
+		tri := Triangulate(points)
+		hull := polygon.ConvexHull(points)
+		assert.Equal(t, 2*len(points)-2-len(hull.Segments), len(tri.Triangles))
+		assertDelaunay(t, tri)
+	})
+
+	t.Run("grid", func(t *testing.T) {
+		var points []point.Point
+		for x := 0; x < 10; x++ {
+			for y := 0; y < 10; y++ {
+				points = append(points, point.NewPoint(float64(x), float64(y)))
+			}
+		}
+		tri := Triangulate(points)
+		assert.Equal(t, 162, len(tri.Triangles))
+		assertDelaunay(t, tri)
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		points := randomPoints(50)
+		shuffled := append([]point.Point{}, points...)
+		rand.New(rand.NewSource(1)).Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		a := Triangulate(points)
+		b := Triangulate(shuffled)
+		assert.Equal(t, a.Triangles, Triangulate(points).Triangles)
+		assert.Equal(t, len(a.Edges()), len(b.Edges()))
+	})
+
+	t.Run("edges and neighbors", func(t *testing.T) {
+		square := []point.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 5, Y: 5}, {X: 5, Y: 5}}
+		tri := Triangulate(square)
+		assert.Equal(t, 4, len(tri.Triangles))
+		assert.Equal(t, 8, len(tri.Edges()))
+		neighbors := tri.Neighbors()
+		assert.Equal(t, []int{1, 3, 4}, neighbors[0])
+		assert.Equal(t, []int{0, 1, 2, 3}, neighbors[4])
+		assert.Equal(t, 0, len(neighbors[5]))
+		assert.Equal(t, 4, len(tri.Polygons()))
+	})
+
+	t.Run("collinear", func(t *testing.T) {
+		tri := Triangulate([]point.Point{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 1, Y: 1}})
+		assert.Equal(t, 0, len(tri.Triangles))
+		assert.Equal(t, [][]int{{2}, {2}, {0, 1}}, tri.Neighbors())
+	})
+}
+
+func TestVoronoi(t *testing.T) {
+	b := bounds.NewBounds(0, 100, 100, 0)
+	points := randomPoints(100)
+	cells := Voronoi(points, b)
+	area := 0.
+	for i, cell := range cells {
+		area += cell.Area()
+		assert.True(t, cell.ContainsPoint(points[i].X, points[i].Y))
+		assert.True(t, cell.IsConvex())
+	}
+	assert.InDelta(t, 10000, area, 1e-6)
+
+	cells = Voronoi([]point.Point{{X: 25, Y: 50}, {X: 75, Y: 50}, {X: 25, Y: 50}}, b)
+	assert.InDelta(t, 5000, cells[0].Area(), 1e-9)
+	assert.Nil(t, cells[2])
+}
+
+func TestRelax(t *testing.T) {
+	b := bounds.NewBounds(0, 100, 100, 0)
+	points := randomPoints(50)
+	spread := func(points []point.Point) float64 {
+		areas := []float64{}
+		for _, cell := range Voronoi(points, b) {
+			areas = append(areas, cell.Area())
+		}
+		min, max := areas[0], areas[0]
+		for _, a := range areas {
+			if a < min {
+				min = a
+			}
+			if a > max {
+				max = a
+			}
+		}
+		return max / min
+	}
+	relaxed := Relax(points, b, 20)
+	assert.True(t, spread(relaxed) < spread(points))
+	assert.True(t, spread(relaxed) < 2)
+	assert.Equal(t, relaxed, Relax(points, b, 20))
+}
+
+func TestRelaxWeighted(t *testing.T) {
+	b := bounds.NewBounds(0, 100, 100, 0)
+	// Dark on the left half only
+	density := func(x, y float64) float64 {
+		if x < 50 {
+			return 1
+		}
+		return 0.05
+	}
+	countLeft := func(points []point.Point) int {
+		left := 0
+		for _, p := range points {
+			if p.X < 50 {
+				left++
+			}
+			assert.True(t, b.Contains(p.X, p.Y))
+		}
+		return left
+	}
+	points := randomPoints(100)
+	relaxed := RelaxWeighted(points, b, density, 1, 10)
+	// Points move towards the dark side
+	assert.True(t, countLeft(relaxed) > countLeft(points))
+
+	// Each point moves to the dark part of its cell
+	quadrant := func(x, y float64) float64 {
+		if y < 50 {
+			return 1
+		}
+		return 0
+	}
+	relaxed = RelaxWeighted([]point.Point{{X: 25, Y: 50}, {X: 75, Y: 50}}, b, quadrant, 1, 1)
+	assert.Equal(t, []point.Point{{X: 25, Y: 25}, {X: 75, Y: 25}}, relaxed)
+}
@@ -0,0 +1,135 @@
+package delaunay
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+// Voronoi returns the Voronoi cell of each of the points clipped to the bounds. The cell of a
+// point is the area that is closer to it than to any other point. Cells are nil for points that
+// repeat an earlier point or whose cell is outside of the bounds.
+func Voronoi(points []point.Point, b bounds.Bounds) []*polygon.Polygon {
+	return Triangulate(points).Voronoi(b)
+}
+
+// Voronoi returns the Voronoi cell of each point of the triangulation clipped to the bounds
+func (t *Triangulation) Voronoi(b bounds.Bounds) []*polygon.Polygon {
+	neighbors := t.Neighbors()
+	frame := []point.Point{*b.TopLeft(), *b.TopRight(), *b.BottomRight(), *b.BottomLeft()}
+	seen := map[point.Point]bool{}
+	ret := make([]*polygon.Polygon, len(t.Points))
+	for i, p := range t.Points {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		cell := frame
+		// The cell is the part of the bounds on p's side of the perpendicular bisector with each
+		// of its neighbours
+		for _, j := range neighbors[i] {
+			cell = clipHalfPlane(cell, p, t.Points[j])
+			if len(cell) < 3 {
+				break
+			}
+		}
+		if len(cell) >= 3 {
+			ret[i] = polygon.NewPolygon(polygon.Points(cell))
+		}
+	}
+	return ret
+}
+
+// clipHalfPlane returns the part of the convex ring that is closer to a than to b
+func clipHalfPlane(ring []point.Point, a, b point.Point) []point.Point {
+	mid := a.AddPoint(b).ScalarMult(0.5)
+	dir := b.SubtractPoint(a)
+	side := func(p point.Point) float64 {
+		return p.SubtractPoint(mid).Dot(dir)
+	}
+	var ret []point.Point
+	for i := range ring {
+		p, q := ring[i], ring[(i+1)%len(ring)]
+		sp, sq := side(p), side(q)
+		if sp <= 0 {
+			ret = append(ret, p)
+		}
+		if (sp < 0 && sq > 0) || (sp > 0 && sq < 0) {
+			ret = append(ret, p.AddPoint(q.SubtractPoint(p).ScalarMult(sp/(sp-sq))))
+		}
+	}
+	return ret
+}
+
+// Relax moves each point to the centroid of its Voronoi cell, which spreads the points out
+// evenly. This is Lloyd's algorithm. Points without a cell are left where they are.
+func Relax(points []point.Point, b bounds.Bounds, iterations int) []point.Point {
+	ret := append([]point.Point{}, points...)
+	for i := 0; i < iterations; i++ {
+		for j, cell := range Voronoi(ret, b) {
+			if cell != nil {
+				ret[j] = cell.Centroid()
+			}
+		}
+	}
+	return ret
+}
+
+// RelaxWeighted moves each point to the centroid of its Voronoi cell weighted by the density, so
+// points gather where the density is high. This is weighted Voronoi stippling. The density is
+// sampled on a grid with the given resolution and should return values from 0 to 1.
+func RelaxWeighted(points []point.Point, b bounds.Bounds, density func(x, y float64) float64, resolution float64, iterations int) []point.Point {
+	ret := append([]point.Point{}, points...)
+	if resolution <= 0 {
+		return ret
+	}
+
+	// Sample the density at the center of each grid square once
+	cols := int(math.Ceil(b.Width() / resolution))
+	rows := int(math.Ceil(b.Height() / resolution))
+	weights := make([]float64, cols*rows)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			x := b.Left + (float64(col)+0.5)*resolution
+			y := b.Top + (float64(row)+0.5)*resolution
+			weights[row*cols+col] = math.Max(density(x, y), 0)
+		}
+	}
+
+	for i := 0; i < iterations; i++ {
+		for j, cell := range Voronoi(ret, b) {
+			if cell == nil {
+				continue
+			}
+			cb := cell.GetBounds()
+			var sum point.Point
+			total := 0.
+			for row := max(int((cb.Top-b.Top)/resolution), 0); row < rows; row++ {
+				y := b.Top + (float64(row)+0.5)*resolution
+				if y > cb.Bottom {
+					break
+				}
+				for col := max(int((cb.Left-b.Left)/resolution), 0); col < cols; col++ {
+					x := b.Left + (float64(col)+0.5)*resolution
+					if x > cb.Right {
+						break
+					}
+					w := weights[row*cols+col]
+					if w == 0 || !cell.ContainsPoint(x, y) {
+						continue
+					}
+					sum = sum.AddPoint(point.NewPoint(x, y).ScalarMult(w))
+					total += w
+				}
+			}
+			if total > 0 {
+				ret[j] = sum.ScalarMult(1 / total)
+			} else {
+				ret[j] = cell.Centroid()
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,64 @@
+package fill
+
+import (
+	"github.com/srmullen/godraw-lib/geometry/d2/offset"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+// Concentric fills the polygon with insets spaced apart by spacing, starting one spacing in from
+// the edge and stopping when the inset vanishes. The outline itself is not included.
+func Concentric(poly *polygon.Polygon, spacing float64) []*polygon.Polygon {
+	var ret []*polygon.Polygon
+	for _, level := range levels(poly, spacing) {
+		ret = append(ret, level...)
+	}
+	return ret
+}
+
+// ConcentricPath fills a closed path with insets spaced apart by spacing. Curves in the path are
+// kept as curves in the insets.
+func ConcentricPath(p *path.Path, spacing float64, opts offset.Options) []*path.Path {
+	if !p.Closed || spacing <= 0 {
+		return nil
+	}
+	var ret []*path.Path
+	for d := spacing; ; d += spacing {
+		insets := offset.Path(p, -d, opts)
+		if len(insets) == 0 {
+			return ret
+		}
+		ret = append(ret, insets...)
+	}
+}
+
+// levels returns the insets of the polygon at each multiple of spacing
+func levels(poly *polygon.Polygon, spacing float64) [][]*polygon.Polygon {
+	if spacing <= 0 {
+		return nil
+	}
+	var ret [][]*polygon.Polygon
+	for d := spacing; ; d += spacing {
+		insets := offset.Polygon(poly, -d, offset.Options{})
+		if len(insets) == 0 {
+			return ret
+		}
+		ret = append(ret, insets)
+	}
+}
+
+// contains returns true if the point is inside the ring using the even-odd rule
+func contains(ring []point.Point, p point.Point) bool {
+	inside := false
+	for i := range ring {
+		a := ring[i]
+		b := ring[(i+1)%len(ring)]
+		if (a.Y <= p.Y && p.Y < b.Y) || (b.Y <= p.Y && p.Y < a.Y) {
+			if x := a.X + (p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y); x < p.X {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
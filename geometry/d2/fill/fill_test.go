@@ -0,0 +1,112 @@
+package fill
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/offset"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcentric(t *testing.T) {
+	rect := polygon.NewRectangle(0, 0, 10, 10)
+	insets := Concentric(rect.Polygon, 2)
+	assert.Equal(t, 2, len(insets))
+	assert.Equal(t, 6., insets[0].GetBounds().Width())
+	assert.Equal(t, 2., insets[1].GetBounds().Width())
+
+	circle := path.NewClosedPath([]float64{0, 0})
+	assert.Equal(t, 0, len(ConcentricPath(circle, 1, offset.Options{})))
+
+	ngon := polygon.NewNgon(6, 0, 0, 10)
+	assert.Equal(t, 4, len(ConcentricPath(ngon.Path, 2, offset.Options{})))
+}
+
+func TestSpiral(t *testing.T) {
+	t.Run("single stroke", func(t *testing.T) {
+		rect := polygon.NewRectangle(0, 0, 20, 20)
+		spirals := Spiral(rect.Polygon, 2)
+		assert.Equal(t, 1, len(spirals))
+		for i := 0.; i < float64(len(spirals[0].Segments)-1); i += 0.5 {
+			x, y := spirals[0].Interpolate(i)
+			assert.True(t, rect.Contains(x, y))
+		}
+		start := spirals[0].Segments[0]
+		assert.Equal(t, 2., start.X)
+		assert.Equal(t, 2., start.Y)
+	})
+
+	t.Run("splits into a spiral per lobe", func(t *testing.T) {
+		// Two squares joined by a bridge that is only wide enough for the first inset
+		dumbbell := polygon.NewPolygon(polygon.Points{
+			{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 7}, {X: 30, Y: 7}, {X: 30, Y: 0}, {X: 50, Y: 0},
+			{X: 50, Y: 20}, {X: 30, Y: 20}, {X: 30, Y: 13}, {X: 20, Y: 13}, {X: 20, Y: 20}, {X: 0, Y: 20},
+		})
+		spirals := Spiral(dumbbell, 2)
+		assert.Equal(t, 3, len(spirals))
+	})
+}
+
+func TestFermatSpiral(t *testing.T) {
+	star := polygon.NewStar(100, 100, 100, 50, 5)
+	spirals := FermatSpiral(star, 5)
+	assert.True(t, len(spirals) > 1)
+	for _, s := range spirals {
+		x, y := s.Interpolate(float64(len(s.Segments)-1) / 2)
+		assert.True(t, star.ContainsPoint(x, y))
+	}
+
+	// Both arms meet at the center in a single stroke
+	square := polygon.NewRectangle(-50, -50, 100, 100)
+	spirals = FermatSpiral(square.Polygon, 5)
+	throughCenter := 0
+	for _, s := range spirals {
+		for _, seg := range s.Segments {
+			if seg.X == 0 && seg.Y == 0 {
+				throughCenter++
+			}
+		}
+	}
+	assert.Equal(t, 1, throughCenter)
+}
+
+func TestSpiralPath(t *testing.T) {
+	// A circle of radius 20 made of two arcs
+	circle := path.FromSegments([]path.Segment{
+		{Point: point.NewPoint(-20, 0), Curve: path.NewArc(20, 20, 0, false, true)},
+		{Point: point.NewPoint(20, 0), Curve: path.NewArc(20, 20, 0, false, true)},
+	}, true)
+	inside := func(s *path.Path) {
+		for _, p := range s.Points() {
+			assert.LessOrEqual(t, p.Magnitude(), 20+1e-9)
+		}
+	}
+
+	spirals := SpiralPath(circle, 2, 0.1)
+	assert.Equal(t, 1, len(spirals))
+	inside(spirals[0])
+	// The spiral starts one spacing in from the curve rather than from the line between its ends
+	start := spirals[0].Segments[0].Point
+	assert.InDelta(t, 18, start.Magnitude(), 0.2)
+
+	spirals = FermatSpiralPath(circle, 2, 0.1)
+	assert.Greater(t, len(spirals), 0)
+	for _, s := range spirals {
+		inside(s)
+	}
+	// The stroke reaches out to the curve
+	far := 0.
+	for _, s := range spirals {
+		for _, p := range s.Points() {
+			far = math.Max(far, p.Magnitude())
+		}
+	}
+	assert.Greater(t, far, 19.)
+
+	circle.Closed = false
+	assert.Nil(t, SpiralPath(circle, 2, 0.1))
+	assert.Nil(t, FermatSpiralPath(circle, 2, 0.1))
+}
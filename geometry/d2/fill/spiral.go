@@ -0,0 +1,231 @@
+package fill
+
+import (
+	"math"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/clip"
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+type inset struct {
+	ring     []point.Point
+	children []*inset
+}
+
+// Spiral fills the polygon with a spiral that starts one spacing in from the edge and winds
+// inward. Each inset is blended into the next so the spiral is drawn as one continuous stroke.
+// Where an inset splits into several pieces the stroke ends and a new spiral is started in each
+// piece.
+func Spiral(poly *polygon.Polygon, spacing float64) []*path.Path {
+	lvls := levels(poly, spacing)
+	if len(lvls) == 0 {
+		return nil
+	}
+
+	// Build the tree of insets. Each inset is inside exactly one inset of the level before it.
+	var roots []*inset
+	var prev []*inset
+	for _, level := range lvls {
+		var current []*inset
+		for _, p := range level {
+			node := &inset{ring: p.Points()}
+			current = append(current, node)
+			parent := -1
+			for i, candidate := range prev {
+				if contains(candidate.ring, node.ring[0]) {
+					parent = i
+					break
+				}
+			}
+			if parent < 0 {
+				roots = append(roots, node)
+			} else {
+				prev[parent].children = append(prev[parent].children, node)
+			}
+		}
+		prev = current
+	}
+
+	var ret []*path.Path
+	var trace func(node *inset, start point.Point, pts []point.Point)
+	trace = func(node *inset, start point.Point, pts []point.Point) {
+		ring := startAt(node.ring, start)
+		if len(node.children) == 1 {
+			child := node.children[0]
+			childStart := closestPoint(child.ring, start)
+			pts = append(pts, blend(ring, startAt(child.ring, childStart), spacing/2)...)
+			trace(child, childStart, pts)
+			return
+		}
+		pts = append(pts, ring...)
+		ret = append(ret, toPath(pts))
+		for _, child := range node.children {
+			trace(child, closestPoint(child.ring, start), nil)
+		}
+	}
+	for _, root := range roots {
+		trace(root, root.ring[0], nil)
+	}
+	return ret
+}
+
+// SpiralPath fills a closed path with a spiral as Spiral does. Curves in the path are replaced by
+// lines within tolerance of them first.
+func SpiralPath(p *path.Path, spacing, tolerance float64) []*path.Path {
+	if !p.Closed {
+		return nil
+	}
+	return Spiral(polygon.FromPath(p, tolerance), spacing)
+}
+
+// FermatSpiral fills the polygon with a double spiral in the style of Fermat's spiral.
+// The spiral winds in to the center of the polygon's bounds and back out again between its own
+// turns, so both ends of the stroke are at the outside. Unlike Fermat's spiral the turns are
+// evenly spaced. The spiral is clipped to the polygon.
+func FermatSpiral(poly *polygon.Polygon, spacing float64) []*path.Path {
+	if spacing <= 0 || len(poly.Segments) < 3 {
+		return nil
+	}
+	ring := poly.Points()
+	center := *poly.GetBounds().Center()
+	radius := 0.
+	for _, p := range ring {
+		radius = math.Max(radius, p.Distance(center))
+	}
+
+	// Each arm is an archimedean spiral that grows by twice the spacing every turn.
+	// The second arm is the first rotated half a turn, so the arms interleave.
+	var arm []point.Point
+	maxTheta := math.Pi*radius/spacing + 2*math.Pi
+	for theta := 0.; theta <= maxTheta; {
+		r := spacing * theta / math.Pi
+		arm = append(arm, point.NewPointFromAngle(theta, r))
+		theta += spacing / (4 * math.Max(r, spacing))
+	}
+	pts := make([]point.Point, 0, len(arm)*2)
+	for i := len(arm) - 1; i >= 0; i-- {
+		pts = append(pts, arm[i].AddPoint(center))
+	}
+	for _, p := range arm[1:] {
+		pts = append(pts, center.SubtractPoint(p))
+	}
+
+	return clip.ClipPathToPolygon(poly, toPath(pts), false)
+}
+
+// FermatSpiralPath fills a closed path with a double spiral as FermatSpiral does. Curves in the
+// path are replaced by lines within tolerance of them first.
+func FermatSpiralPath(p *path.Path, spacing, tolerance float64) []*path.Path {
+	if !p.Closed {
+		return nil
+	}
+	return FermatSpiral(polygon.FromPath(p, tolerance), spacing)
+}
+
+// startAt returns the closed ring starting and ending at p, which must be on the ring.
+func startAt(ring []point.Point, p point.Point) []point.Point {
+	edge := closestEdge(ring, p)
+	ret := make([]point.Point, 0, len(ring)+2)
+	ret = append(ret, p)
+	for i := 1; i <= len(ring); i++ {
+		ret = append(ret, ring[(edge+i)%len(ring)])
+	}
+	return append(ret, p)
+}
+
+// closestEdge returns the index of the edge of the ring that is closest to p
+func closestEdge(ring []point.Point, p point.Point) int {
+	ret := 0
+	best := math.Inf(1)
+	for i := range ring {
+		a := ring[i]
+		b := ring[(i+1)%len(ring)]
+		if d := line.DistanceToPoint(a.X, a.Y, b.X, b.Y, p.X, p.Y); d < best {
+			best = d
+			ret = i
+		}
+	}
+	return ret
+}
+
+func closestPoint(ring []point.Point, p point.Point) point.Point {
+	i := closestEdge(ring, p)
+	a := ring[i]
+	b := ring[(i+1)%len(ring)]
+	x, y, _ := line.ClosestPoint(a.X, a.Y, b.X, b.Y, p.X, p.Y)
+	return point.NewPoint(x, y)
+}
+
+// blend moves from the polyline a to the polyline b. At a fraction u of the way along a, the
+// point returned is u of the way between a and the point the same fraction along b. The end of
+// b is not included.
+func blend(a, b []point.Point, step float64) []point.Point {
+	la := cumulativeLengths(a)
+	lb := cumulativeLengths(b)
+	totalA := la[len(la)-1]
+	totalB := lb[len(lb)-1]
+	if totalA == 0 || totalB == 0 {
+		return a
+	}
+
+	// Sample at the vertices of both polylines so that corners are kept
+	us := []float64{}
+	for _, l := range la {
+		us = append(us, l/totalA)
+	}
+	for _, l := range lb {
+		us = append(us, l/totalB)
+	}
+	n := int(math.Ceil(totalA / step))
+	for i := 0; i < n; i++ {
+		us = append(us, float64(i)/float64(n))
+	}
+	sort.Float64s(us)
+
+	var ret []point.Point
+	for i, u := range us {
+		if u >= 1 || (i > 0 && u-us[i-1] < 1e-9) {
+			continue
+		}
+		pa := pointAt(a, la, u*totalA)
+		pb := pointAt(b, lb, u*totalB)
+		ret = append(ret, pa.AddPoint(pb.SubtractPoint(pa).ScalarMult(u)))
+	}
+	return ret
+}
+
+func cumulativeLengths(pts []point.Point) []float64 {
+	ret := make([]float64, len(pts))
+	for i := 1; i < len(pts); i++ {
+		ret[i] = ret[i-1] + pts[i].Distance(pts[i-1])
+	}
+	return ret
+}
+
+// pointAt returns the point at distance s along the polyline
+func pointAt(pts []point.Point, lengths []float64, s float64) point.Point {
+	i := sort.SearchFloat64s(lengths, s)
+	if i == 0 {
+		return pts[0]
+	}
+	if i >= len(pts) {
+		return pts[len(pts)-1]
+	}
+	segment := lengths[i] - lengths[i-1]
+	if segment == 0 {
+		return pts[i]
+	}
+	return pts[i-1].AddPoint(pts[i].SubtractPoint(pts[i-1]).ScalarMult((s - lengths[i-1]) / segment))
+}
+
+func toPath(pts []point.Point) *path.Path {
+	coords := make([]float64, 0, len(pts)*2)
+	for _, p := range pts {
+		coords = append(coords, p.X, p.Y)
+	}
+	return path.NewOpenPath(coords)
+}
@@ -0,0 +1,349 @@
+package flowfield
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/poisson"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+// A Field returns the direction of the flow at x, y in radians
+type Field func(x, y float64) float64
+
+// NoiseField turns noise into a field. The noise is sampled at x, y times frequency and each
+// unit of noise turns the flow by turns full circles.
+func NoiseField(n func(x, y float64) float64, frequency, turns float64) Field {
+	return func(x, y float64) float64 {
+		return n(x*frequency, y*frequency) * turns * 2 * math.Pi
+	}
+}
+
+// Options control how streamlines are traced.
+type Options struct {
+	// Step is the distance between the points of a streamline. Defaults to half of Separation,
+	// or a hundredth of the smaller side of the bounds if there is no separation.
+	Step float64
+	// MaxLength is the longest a streamline can be. Defaults to the perimeter of the bounds.
+	MaxLength float64
+	// MinLength drops streamlines shorter than it
+	MinLength float64
+	// Separation is the closest streamlines can come to each other, including other parts of
+	// themselves. Zero lets lines cross.
+	Separation float64
+	// SeedSpacing is how far new streamlines start from existing ones. When there is a
+	// separation each streamline seeds new ones alongside it at this distance, as described by
+	// Jobard and Lefer. Defaults to twice the separation.
+	SeedSpacing float64
+	// Seeds are the points streamlines are started from, in order. Seeds closer than
+	// SeedSpacing to an existing line are skipped. Defaults to a grid at SeedSpacing.
+	Seeds []point.Point
+	// Boundary stops streamlines where they cross it
+	Boundary *polygon.Polygon
+}
+
+func (o Options) step(b bounds.Bounds) float64 {
+	if o.Step > 0 {
+		return o.Step
+	}
+	if o.Separation > 0 {
+		return o.Separation / 2
+	}
+	return math.Min(b.Width(), b.Height()) / 100
+}
+
+func (o Options) maxLength(b bounds.Bounds) float64 {
+	if o.MaxLength <= 0 {
+		return 2 * (b.Width() + b.Height())
+	}
+	return o.MaxLength
+}
+
+func (o Options) seedSpacing(b bounds.Bounds) float64 {
+	if o.SeedSpacing > 0 {
+		return o.SeedSpacing
+	}
+	if o.Separation > 0 {
+		return 2 * o.Separation
+	}
+	return math.Min(b.Width(), b.Height()) / 20
+}
+
+// GridSeeds returns points at the centers of a grid of square cells covering the bounds
+func GridSeeds(b bounds.Bounds, spacing float64) []point.Point {
+	var ret []point.Point
+	if spacing <= 0 {
+		return ret
+	}
+	for y := b.Top + spacing/2; y < b.Bottom; y += spacing {
+		for x := b.Left + spacing/2; x < b.Right; x += spacing {
+			ret = append(ret, point.NewPoint(x, y))
+		}
+	}
+	return ret
+}
+
+// RandomSeeds returns n points spread uniformly over the bounds
+func RandomSeeds(b bounds.Bounds, n int, rng *rand.Rand) []point.Point {
+	ret := make([]point.Point, n)
+	for i := range ret {
+		ret[i] = point.NewPoint(b.Left+rng.Float64()*b.Width(), b.Top+rng.Float64()*b.Height())
+	}
+	return ret
+}
+
+// PoissonSeeds returns points in the bounds that are at least radius apart
+func PoissonSeeds(b bounds.Bounds, radius float64, rng *rand.Rand) []point.Point {
+	return poisson.Sample(poisson.Options{Radius: radius, Rand: rng}, b)
+}
+
+// Streamlines traces the field from each seed forwards and backwards until the line leaves the
+// bounds or boundary, comes too close to another line, reaches its maximum length or the field
+// is NaN.
+func Streamlines(opts Options, field Field, b bounds.Bounds) []*path.Path {
+	t := &tracer{
+		opts:      opts,
+		field:     field,
+		b:         b,
+		step:      opts.step(b),
+		maxLength: opts.maxLength(b),
+		spacing:   opts.seedSpacing(b),
+	}
+	t.grid = newGrid(math.Max(opts.Separation, t.step))
+
+	seeds := opts.Seeds
+	if seeds == nil {
+		seeds = GridSeeds(b, t.spacing)
+	}
+	var ret []*path.Path
+	var queue []point.Point
+	for len(seeds) > 0 || len(queue) > 0 {
+		// Seeds alongside the last lines are used first so the lines fill the space in order
+		var seed point.Point
+		if len(queue) > 0 {
+			seed, queue = queue[0], queue[1:]
+		} else {
+			seed, seeds = seeds[0], seeds[1:]
+		}
+		pts := t.streamline(seed)
+		if pts == nil {
+			continue
+		}
+		coords := make([]float64, 0, 2*len(pts))
+		for _, p := range pts {
+			coords = append(coords, p.X, p.Y)
+		}
+		ret = append(ret, path.NewOpenPath(coords))
+		if opts.Separation > 0 {
+			queue = append(queue, t.neighbours(pts)...)
+		}
+	}
+	return ret
+}
+
+type tracer struct {
+	opts      Options
+	field     Field
+	b         bounds.Bounds
+	step      float64
+	maxLength float64
+	spacing   float64
+	grid      *grid
+	lines     int
+}
+
+func (t *tracer) inside(p point.Point) bool {
+	if !t.b.Contains(p.X, p.Y) {
+		return false
+	}
+	return t.opts.Boundary == nil || t.opts.Boundary.ContainsPoint(p.X, p.Y)
+}
+
+// valid returns true if a line can start at p
+func (t *tracer) valid(p point.Point) bool {
+	if !t.inside(p) || math.IsNaN(t.field(p.X, p.Y)) {
+		return false
+	}
+	return t.opts.Separation <= 0 || !t.grid.near(p, t.spacing*(1-1e-9), nil)
+}
+
+// streamline traces a line through the seed and adds it to the grid. It returns nil if the line
+// is too short.
+func (t *tracer) streamline(seed point.Point) []point.Point {
+	if !t.valid(seed) {
+		return nil
+	}
+	id := t.lines
+	t.lines++
+	forward := t.trace(seed, 1, id)
+	backward := t.trace(seed, -1, id)
+
+	pts := make([]point.Point, 0, len(forward)+len(backward)+1)
+	for i := len(backward) - 1; i >= 0; i-- {
+		pts = append(pts, backward[i])
+	}
+	pts = append(pts, seed)
+	pts = append(pts, forward...)
+
+	length := 0.
+	for i := 1; i < len(pts); i++ {
+		length += pts[i].Distance(pts[i-1])
+	}
+	if len(pts) < 2 || length < t.opts.MinLength {
+		t.grid.remove(id)
+		return nil
+	}
+	return pts
+}
+
+// trace follows the field from start in the direction dir, which is 1 for forwards and -1 for
+// backwards. The points after start are returned.
+func (t *tracer) trace(start point.Point, dir float64, id int) []point.Point {
+	var ret []point.Point
+	sep := t.opts.Separation
+	p := start
+	// s is the distance along the line from the seed, negative when tracing backwards
+	s := 0.
+	for math.Abs(s) < t.maxLength {
+		next, ok := t.advance(p, dir*math.Min(t.step, t.maxLength-math.Abs(s)))
+		if !ok || next.Equals(p) {
+			break
+		}
+		stop := false
+		if !t.inside(next) {
+			next = t.exit(p, next)
+			stop = true
+			if next.Equals(p) {
+				break
+			}
+		}
+		ds := dir * next.Distance(p)
+		if sep > 0 {
+			// A line's own recent points are always close, so only parts of it that are far
+			// back along its length count
+			mid := s + ds/2
+			if t.grid.near(next, sep, func(seg segment) bool {
+				return seg.line == id && math.Abs(seg.s-mid) < 2*sep
+			}) {
+				break
+			}
+			t.grid.add(segment{a: p, b: next, line: id, s: mid})
+		}
+		ret = append(ret, next)
+		p = next
+		s += ds
+		if stop {
+			break
+		}
+	}
+	return ret
+}
+
+// advance moves h along the field from p using the midpoint method
+func (t *tracer) advance(p point.Point, h float64) (point.Point, bool) {
+	a := t.field(p.X, p.Y)
+	mid := p.AddPoint(point.NewPointFromAngle(a, h/2))
+	a = t.field(mid.X, mid.Y)
+	if math.IsNaN(a) {
+		return p, false
+	}
+	return p.AddPoint(point.NewPointFromAngle(a, h)), true
+}
+
+// exit returns the point where the step from the inside point a to the outside point b leaves
+// the bounds or boundary
+func (t *tracer) exit(a, b point.Point) point.Point {
+	for i := 0; i < 40; i++ {
+		mid := a.AddPoint(b).ScalarMult(0.5)
+		if t.inside(mid) {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+	return a
+}
+
+// neighbours returns points on both sides of the line at the seed spacing
+func (t *tracer) neighbours(pts []point.Point) []point.Point {
+	var ret []point.Point
+	dist := 0.
+	for i := 1; i < len(pts); i++ {
+		dist += pts[i].Distance(pts[i-1])
+		if dist < t.spacing && i < len(pts)-1 {
+			continue
+		}
+		dist = 0
+		normal := pts[i].SubtractPoint(pts[i-1]).Normal().Normalize().ScalarMult(t.spacing)
+		ret = append(ret, pts[i].AddPoint(normal), pts[i].SubtractPoint(normal))
+	}
+	return ret
+}
+
+type segment struct {
+	a, b point.Point
+	line int
+	// s is the distance along the line from its seed to the middle of the segment
+	s float64
+}
+
+// grid indexes segments by the cell of their first point. Segments are no longer than the
+// cell size.
+type grid struct {
+	size  float64
+	cells map[[2]int][]segment
+	// lines holds the cells each line has segments in
+	lines map[int][][2]int
+}
+
+func newGrid(size float64) *grid {
+	return &grid{size: size, cells: map[[2]int][]segment{}, lines: map[int][][2]int{}}
+}
+
+func (g *grid) cell(p point.Point) [2]int {
+	return [2]int{int(math.Floor(p.X / g.size)), int(math.Floor(p.Y / g.size))}
+}
+
+func (g *grid) add(s segment) {
+	c := g.cell(s.a)
+	g.cells[c] = append(g.cells[c], s)
+	g.lines[s.line] = append(g.lines[s.line], c)
+}
+
+// remove removes all of the segments of the line
+func (g *grid) remove(id int) {
+	for _, c := range g.lines[id] {
+		kept := g.cells[c][:0]
+		for _, s := range g.cells[c] {
+			if s.line != id {
+				kept = append(kept, s)
+			}
+		}
+		g.cells[c] = kept
+	}
+	delete(g.lines, id)
+}
+
+// near returns true if a segment that is not skipped is closer than d to p
+func (g *grid) near(p point.Point, d float64, skip func(s segment) bool) bool {
+	// A segment starting in a cell reaches at most one cell size out of it
+	r := int(math.Ceil(d/g.size)) + 1
+	c := g.cell(p)
+	for i := c[0] - r; i <= c[0]+r; i++ {
+		for j := c[1] - r; j <= c[1]+r; j++ {
+			for _, s := range g.cells[[2]int{i, j}] {
+				if skip != nil && skip(s) {
+					continue
+				}
+				if line.DistanceToPoint(s.a.X, s.a.Y, s.b.X, s.b.Y, p.X, p.Y) < d {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package flowfield
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+// distance returns the smallest distance from the points of a to the segments of b, skipping
+// points of a within skip of the segment's position along the line when they are the same line
+func distance(a, b *path.Path, skip int) float64 {
+	ret := math.Inf(1)
+	pa, pb := a.Points(), b.Points()
+	for i, p := range pa {
+		for j := 0; j < len(pb)-1; j++ {
+			if a == b && math.Abs(float64(i-j)) < float64(skip) {
+				continue
+			}
+			ret = math.Min(ret, line.DistanceToPoint(pb[j].X, pb[j].Y, pb[j+1].X, pb[j+1].Y, p.X, p.Y))
+		}
+	}
+	return ret
+}
+
+func TestStreamlines(t *testing.T) {
+	b := bounds.NewBounds(0, 100, 100, 0)
+
+	t.Run("uniform field", func(t *testing.T) {
+		lines := Streamlines(Options{Step: 1, Seeds: []point.Point{{X: 50, Y: 50}}}, func(x, y float64) float64 { return 0 }, b)
+		assert.Equal(t, 1, len(lines))
+		pts := lines[0].Points()
+		assert.InDelta(t, 0, pts[0].X, 1e-9)
+		assert.InDelta(t, 100, pts[len(pts)-1].X, 1e-9)
+		for _, p := range pts {
+			assert.InDelta(t, 50, p.Y, 1e-9)
+		}
+	})
+
+	t.Run("max length", func(t *testing.T) {
+		lines := Streamlines(Options{Step: 1, MaxLength: 10, Seeds: []point.Point{{X: 50, Y: 50}}}, func(x, y float64) float64 { return math.Pi / 2 }, b)
+		pts := lines[0].Points()
+		assert.InDelta(t, 40, pts[0].Y, 1e-9)
+		assert.InDelta(t, 60, pts[len(pts)-1].Y, 1e-9)
+	})
+
+	t.Run("separation", func(t *testing.T) {
+		// A vortex around the center
+		field := func(x, y float64) float64 {
+			return math.Atan2(y-50, x-50) + math.Pi/2 + 0.3*math.Sin(x/7)
+		}
+		lines := Streamlines(Options{Separation: 4}, field, b)
+		assert.Greater(t, len(lines), 10)
+		for i, a := range lines {
+			for _, p := range a.Points() {
+				assert.True(t, b.Contains(p.X, p.Y))
+			}
+			// Own points more than two separations apart along the line
+			assert.GreaterOrEqual(t, distance(a, a, 2*4/2+1), 4*0.99)
+			for _, other := range lines[:i] {
+				assert.GreaterOrEqual(t, distance(a, other, 0), 4*0.99)
+			}
+		}
+	})
+
+	t.Run("boundary", func(t *testing.T) {
+		circle := polygon.NewStar(50, 50, 40, 40, 30)
+		lines := Streamlines(Options{Separation: 5, Boundary: circle}, func(x, y float64) float64 { return 0.4 }, b)
+		assert.Greater(t, len(lines), 5)
+		for _, l := range lines {
+			pts := l.Points()
+			for _, p := range pts {
+				assert.True(t, circle.ContainsPoint(p.X, p.Y))
+			}
+			// Lines run right up to the boundary
+			assert.Less(t, circle.Distance(pts[0].X, pts[0].Y), 1e-6)
+			assert.Less(t, circle.Distance(pts[len(pts)-1].X, pts[len(pts)-1].Y), 1e-6)
+		}
+	})
+}
+
+func TestSeeds(t *testing.T) {
+	b := bounds.NewBounds(0, 10, 20, 0)
+	grid := GridSeeds(b, 5)
+	assert.Equal(t, 8, len(grid))
+	assert.Equal(t, point.NewPoint(2.5, 2.5), grid[0])
+
+	rng := rand.New(rand.NewSource(1))
+	for _, p := range RandomSeeds(b, 50, rng) {
+		assert.True(t, b.Contains(p.X, p.Y))
+	}
+	pts := PoissonSeeds(b, 2, rng)
+	assert.Greater(t, len(pts), 10)
+	for i, p := range pts {
+		for _, q := range pts[:i] {
+			assert.GreaterOrEqual(t, p.Distance(q), 2.)
+		}
+	}
+}
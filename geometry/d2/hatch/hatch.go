@@ -0,0 +1,253 @@
+package hatch
+
+import (
+	"math"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+// Options control the lines generated by FillOptions.
+type Options struct {
+	// Angle of the hatch lines in radians
+	Angle float64
+	// Spacing is the distance between neighbouring hatch lines
+	Spacing float64
+	// Offset shifts the hatch lines perpendicular to their direction
+	Offset float64
+	// Cross adds a second set of lines perpendicular to the first
+	Cross bool
+	// Alternate reverses the direction of every other line so the pen zig-zags
+	Alternate bool
+	// Serpentine joins the ends of neighbouring lines into continuous strokes wherever the
+	// connecting line stays inside the shape. This minimises pen lifts.
+	Serpentine bool
+}
+
+// A span is the part of a scanline that is inside the shape
+type span struct {
+	x0, x1 float64
+}
+
+// Fill returns parallel lines at the given angle (radians) and spacing that fill the polygon.
+// Any holes are left empty.
+func Fill(poly *polygon.Polygon, angle, spacing float64, holes ...*polygon.Polygon) []*path.Path {
+	return FillOptions(Options{Angle: angle, Spacing: spacing}, poly, holes...)
+}
+
+// FillOptions fills the polygon according to the options. Any holes are left empty.
+func FillOptions(opts Options, poly *polygon.Polygon, holes ...*polygon.Polygon) []*path.Path {
+	rings := make([][]point.Point, 0, len(holes)+1)
+	rings = append(rings, poly.Points())
+	for _, hole := range holes {
+		rings = append(rings, hole.Points())
+	}
+	return FillRings(opts, rings)
+}
+
+// FillMultiPolygon fills each polygon of the multipolygon leaving its holes empty
+func FillMultiPolygon(opts Options, m polygon.MultiPolygon) []*path.Path {
+	return FillRings(opts, m.Rings())
+}
+
+// FillRings fills the area enclosed by the rings using the even-odd rule.
+func FillRings(opts Options, rings [][]point.Point) []*path.Path {
+	if opts.Spacing <= 0 {
+		return nil
+	}
+	ret := fillAngle(opts, opts.Angle, rings)
+	if opts.Cross {
+		ret = append(ret, fillAngle(opts, opts.Angle+math.Pi/2, rings)...)
+	}
+	return ret
+}
+
+// scan returns the horizontal spans of the rings at each y value. The spans on each line are
+// sorted from left to right.
+func scan(rings [][]point.Point, ys []float64) [][]span {
+	ret := make([][]span, len(ys))
+	for i, y := range ys {
+		xs := crossings(rings, y)
+		for j := 0; j+1 < len(xs); j += 2 {
+			if xs[j+1] > xs[j] {
+				ret[i] = append(ret[i], span{xs[j], xs[j+1]})
+			}
+		}
+	}
+	return ret
+}
+
+// crossings returns the sorted x values where the horizontal line at y crosses the rings.
+// Edges include their lower end point but not their upper one, so a line through a vertex is
+// counted once if the boundary crosses it and zero or two times if it only touches it.
+func crossings(rings [][]point.Point, y float64) []float64 {
+	var xs []float64
+	for _, ring := range rings {
+		for i := range ring {
+			a := ring[i]
+			b := ring[(i+1)%len(ring)]
+			if (a.Y <= y && y < b.Y) || (b.Y <= y && y < a.Y) {
+				xs = append(xs, a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y))
+			}
+		}
+	}
+	sort.Float64s(xs)
+	return xs
+}
+
+// fillAngle rotates the rings so the hatch lines are horizontal, scans them and rotates the
+// resulting lines back.
+func fillAngle(opts Options, angle float64, rings [][]point.Point) []*path.Path {
+	rotated, ys, _ := scanlines(rings, angle, opts.Spacing, opts.Offset)
+	rows := scan(rotated, ys)
+
+	var lines [][]point.Point
+	if opts.Serpentine {
+		lines = serpentine(rotated, ys, rows)
+	} else {
+		for i, row := range rows {
+			for _, s := range row {
+				l := []point.Point{point.NewPoint(s.x0, ys[i]), point.NewPoint(s.x1, ys[i])}
+				if opts.Alternate && i%2 == 1 {
+					l[0], l[1] = l[1], l[0]
+				}
+				lines = append(lines, l)
+			}
+		}
+	}
+
+	return toPaths(lines, angle)
+}
+
+// scanlines rotates the rings by -angle and returns the y values of the horizontal hatch lines
+// that cross them, along with the index of each line counted from y = offset.
+func scanlines(rings [][]point.Point, angle, spacing, offset float64) ([][]point.Point, []float64, []int) {
+	rotated := make([][]point.Point, len(rings))
+	minY := math.Inf(1)
+	maxY := math.Inf(-1)
+	for i, ring := range rings {
+		rotated[i] = make([]point.Point, len(ring))
+		for j, p := range ring {
+			r := p.Rotate(-angle)
+			rotated[i][j] = r
+			minY = math.Min(minY, r.Y)
+			maxY = math.Max(maxY, r.Y)
+		}
+	}
+	if math.IsInf(minY, 0) {
+		return rotated, nil, nil
+	}
+
+	// Lines are aligned to multiples of the spacing so neighbouring shapes hatch seamlessly.
+	// Lines that only touch the top or bottom of the shape are skipped even if rotation has
+	// moved them slightly inside.
+	var ys []float64
+	var indices []int
+	eps := 1e-9 * spacing
+	first := int(math.Floor((minY - offset) / spacing))
+	for i := first; ; i++ {
+		y := float64(i)*spacing + offset
+		if y >= maxY-eps {
+			break
+		}
+		if y > minY+eps {
+			ys = append(ys, y)
+			indices = append(indices, i)
+		}
+	}
+	return rotated, ys, indices
+}
+
+// toPaths rotates the polylines by angle and converts them to open paths
+func toPaths(lines [][]point.Point, angle float64) []*path.Path {
+	ret := make([]*path.Path, len(lines))
+	for i, l := range lines {
+		coords := make([]float64, 0, len(l)*2)
+		for _, p := range l {
+			r := p.Rotate(angle)
+			coords = append(coords, r.X, r.Y)
+		}
+		ret[i] = path.NewOpenPath(coords)
+	}
+	return ret
+}
+
+// serpentine joins spans on neighbouring rows into continuous polylines. Each span is joined
+// to the chain on the previous row whose end is closest, as long as the connecting line stays
+// inside the rings.
+func serpentine(rings [][]point.Point, ys []float64, rows [][]span) [][]point.Point {
+	var done [][]point.Point
+	var open [][]point.Point
+	for i, row := range rows {
+		var next [][]point.Point
+		used := make([]bool, len(open))
+		for _, s := range row {
+			left := point.NewPoint(s.x0, ys[i])
+			right := point.NewPoint(s.x1, ys[i])
+			best := -1
+			bestDist := math.Inf(1)
+			for j, chain := range open {
+				if used[j] {
+					continue
+				}
+				end := chain[len(chain)-1]
+				target := left
+				if math.Abs(end.X-right.X) < math.Abs(end.X-left.X) {
+					target = right
+				}
+				if d := end.Distance(target); d < bestDist && isInside(rings, end, target) {
+					best = j
+					bestDist = d
+				}
+			}
+			if best < 0 {
+				if i%2 == 1 {
+					left, right = right, left
+				}
+				next = append(next, []point.Point{left, right})
+				continue
+			}
+			used[best] = true
+			chain := open[best]
+			end := chain[len(chain)-1]
+			if math.Abs(end.X-right.X) < math.Abs(end.X-left.X) {
+				left, right = right, left
+			}
+			next = append(next, append(chain, left, right))
+		}
+		for j, chain := range open {
+			if !used[j] {
+				done = append(done, chain)
+			}
+		}
+		open = next
+	}
+	return append(done, open...)
+}
+
+// isInside returns true if the line from a to b does not cross the boundary of the rings and
+// its midpoint is inside or on them.
+func isInside(rings [][]point.Point, a, b point.Point) bool {
+	for _, ring := range rings {
+		for i := range ring {
+			c := ring[i]
+			d := ring[(i+1)%len(ring)]
+			if t, _, ok := line.GetIntersectionParams(a.X, a.Y, b.X, b.Y, c.X, c.Y, d.X, d.Y); ok && t > 1e-9 && t < 1-1e-9 {
+				return false
+			}
+		}
+	}
+	// The line often runs along the boundary so points on it count as inside
+	mid := a.AddPoint(b).ScalarMult(0.5)
+	eps := 1e-9 * (1 + math.Abs(mid.X))
+	xs := crossings(rings, mid.Y)
+	for j := 0; j+1 < len(xs); j += 2 {
+		if xs[j]-eps <= mid.X && mid.X <= xs[j+1]+eps {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package hatch
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFill(t *testing.T) {
+	t.Run("horizontal lines", func(t *testing.T) {
+		rect := polygon.NewRectangle(0, 0, 10, 10)
+		lines := Fill(rect.Polygon, 0, 2)
+		// Lines along the top and bottom edges are not included
+		assert.Equal(t, 4, len(lines))
+		for i, l := range lines {
+			assert.Equal(t, []point.Point{{X: 0, Y: float64(2 * (i + 1))}, {X: 10, Y: float64(2 * (i + 1))}}, l.Points())
+		}
+	})
+
+	t.Run("lines through vertices", func(t *testing.T) {
+		// A diamond with vertices on the scanlines at y = 0, 5 and 10
+		diamond := polygon.NewPolygon(polygon.Points{{X: 5, Y: 0}, {X: 10, Y: 5}, {X: 5, Y: 10}, {X: 0, Y: 5}})
+		lines := Fill(diamond, 0, 5)
+		assert.Equal(t, 1, len(lines))
+		assert.Equal(t, []point.Point{{X: 0, Y: 5}, {X: 10, Y: 5}}, lines[0].Points())
+	})
+
+	t.Run("concave polygon", func(t *testing.T) {
+		// A U shape whose inner corners sit on a scanline
+		u := polygon.NewPolygon(polygon.Points{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 6}, {X: 6, Y: 6}, {X: 6, Y: 0}, {X: 9, Y: 0}, {X: 9, Y: 9}, {X: 0, Y: 9}})
+		lines := Fill(u, 0, 3)
+		assert.Equal(t, 3, len(lines))
+		assert.Equal(t, 3., lines[0].Segments[1].X)
+		assert.Equal(t, 6., lines[1].Segments[0].X)
+		assert.Equal(t, []point.Point{{X: 0, Y: 6}, {X: 9, Y: 6}}, lines[2].Points())
+	})
+
+	t.Run("holes", func(t *testing.T) {
+		outer := polygon.NewRectangle(0, 0, 10, 10)
+		hole := polygon.NewRectangle(3, 3, 4, 4)
+		lines := Fill(outer.Polygon, 0, 2, hole.Polygon)
+		// Lines at y = 4 and 6 are split by the hole
+		assert.Equal(t, 6, len(lines))
+		assert.Equal(t, 3., lines[1].Segments[1].X)
+		assert.Equal(t, 7., lines[2].Segments[0].X)
+	})
+
+	t.Run("angled lines stay inside", func(t *testing.T) {
+		star := polygon.NewStar(100, 100, 100, 50, 5)
+		for _, l := range Fill(star, math.Pi/3, 7) {
+			x, y := l.Interpolate(0.5)
+			assert.True(t, star.ContainsPoint(x, y))
+		}
+	})
+}
+
+func TestFillOptions(t *testing.T) {
+	rect := polygon.NewRectangle(0, 0, 10, 10)
+
+	t.Run("cross hatch", func(t *testing.T) {
+		lines := FillOptions(Options{Spacing: 2, Cross: true}, rect.Polygon)
+		assert.Equal(t, 8, len(lines))
+	})
+
+	t.Run("alternate", func(t *testing.T) {
+		lines := FillOptions(Options{Spacing: 2, Alternate: true}, rect.Polygon)
+		assert.Equal(t, 0., lines[0].Segments[0].X)
+		assert.Equal(t, 10., lines[1].Segments[0].X)
+	})
+
+	t.Run("serpentine", func(t *testing.T) {
+		lines := FillOptions(Options{Spacing: 2, Serpentine: true}, rect.Polygon)
+		assert.Equal(t, 1, len(lines))
+		assert.Equal(t, 8, len(lines[0].Segments))
+
+		u := polygon.NewPolygon(polygon.Points{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 6}, {X: 6, Y: 6}, {X: 6, Y: 0}, {X: 9, Y: 0}, {X: 9, Y: 9}, {X: 0, Y: 9}})
+		lines = FillOptions(Options{Spacing: 1, Serpentine: true}, u)
+		for _, l := range lines {
+			for i := 0; i < len(l.Segments)-1; i++ {
+				x, y := l.Interpolate(float64(i) + 0.5)
+				assert.True(t, u.ContainsPoint(x, y))
+			}
+		}
+		assert.Equal(t, 2, len(lines))
+	})
+}
+
+func TestFillMultiPolygon(t *testing.T) {
+	donut := polygon.MultiPolygon{polygon.NewPolygonWithHoles(polygon.NewRectangle(0, 0, 10, 10).Polygon, polygon.NewRectangle(2, 2, 6, 6).Polygon)}
+	lines := FillMultiPolygon(Options{Spacing: 5}, donut)
+	assert.Equal(t, 2, len(lines))
+	assert.Equal(t, []point.Point{{X: 0, Y: 5}, {X: 2, Y: 5}}, lines[0].Points())
+	assert.Equal(t, []point.Point{{X: 8, Y: 5}, {X: 10, Y: 5}}, lines[1].Points())
+}
@@ -0,0 +1,237 @@
+package hatch
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/srmullen/godraw-lib/util"
+)
+
+// A Field returns the darkness at x, y from 0 (white) to 1 (black)
+type Field func(x, y float64) float64
+
+// A Tone maps a field value to the darkness that is drawn
+type Tone func(v float64) float64
+
+// LinearTone draws the field value as it is
+func LinearTone(v float64) float64 {
+	return util.Clamp(v, 0, 1)
+}
+
+// GammaTone returns a Tone that raises the field value to the power gamma.
+// Values of gamma above 1 lighten the midtones and values below 1 darken them.
+func GammaTone(gamma float64) Tone {
+	return func(v float64) float64 {
+		return math.Pow(util.Clamp(v, 0, 1), gamma)
+	}
+}
+
+// BandTone returns a Tone that posterizes the field into n flat bands.
+// Combined with one level per layer each band adds exactly one more layer of hatching.
+func BandTone(n int) Tone {
+	return func(v float64) float64 {
+		v = util.Clamp(v, 0, 1)
+		if n < 2 {
+			return math.Round(v)
+		}
+		if v >= 1 {
+			return 1
+		}
+		return math.Floor(v*float64(n)) / float64(n-1)
+	}
+}
+
+// ShadeOptions control the lines generated by Shade.
+type ShadeOptions struct {
+	// Spacing is the distance between hatch lines in the darkest areas
+	Spacing float64
+	// Angles of each layer of hatching in radians. With n layers, layer i is drawn where the
+	// tone is greater than i/n so dark regions are covered by more layers.
+	Angles []float64
+	// Levels is the number of spacings each layer can be drawn with. Within its band of tone a
+	// layer drops lines in a dithered order, so the space between the lines that remain grows
+	// as the tone gets lighter. 1 draws each layer at a single spacing.
+	Levels int
+	// Tone maps field values to darkness. Defaults to LinearTone.
+	Tone Tone
+	// Resolution is the distance between samples of the field along each line.
+	// Defaults to half the spacing.
+	Resolution float64
+	// MinLength drops dashes shorter than it
+	MinLength float64
+}
+
+func (o ShadeOptions) angles() []float64 {
+	if len(o.Angles) == 0 {
+		return []float64{0}
+	}
+	return o.Angles
+}
+
+func (o ShadeOptions) levels() int {
+	if o.Levels < 1 {
+		return 1
+	}
+	return o.Levels
+}
+
+func (o ShadeOptions) tone() Tone {
+	if o.Tone == nil {
+		return LinearTone
+	}
+	return o.Tone
+}
+
+func (o ShadeOptions) resolution() float64 {
+	if o.Resolution <= 0 {
+		return o.Spacing / 2
+	}
+	return o.Resolution
+}
+
+// ImageField samples the image stretched over the bounds. Dark pixels have high values.
+// Points outside of the bounds are white.
+func ImageField(img image.Image, b bounds.Bounds) Field {
+	rect := img.Bounds()
+	return func(x, y float64) float64 {
+		if !b.Contains(x, y) || b.Width() == 0 || b.Height() == 0 {
+			return 0
+		}
+		px := rect.Min.X + int((x-b.Left)/b.Width()*float64(rect.Dx()))
+		py := rect.Min.Y + int((y-b.Top)/b.Height()*float64(rect.Dy()))
+		px = min(px, rect.Max.X-1)
+		py = min(py, rect.Max.Y-1)
+		gray := color.Gray16Model.Convert(img.At(px, py)).(color.Gray16)
+		return 1 - float64(gray.Y)/0xffff
+	}
+}
+
+// Shade hatches the polygon with a density that follows the field. Any holes are left empty.
+func Shade(opts ShadeOptions, field Field, poly *polygon.Polygon, holes ...*polygon.Polygon) []*path.Path {
+	rings := make([][]point.Point, 0, len(holes)+1)
+	rings = append(rings, poly.Points())
+	for _, hole := range holes {
+		rings = append(rings, hole.Points())
+	}
+	return ShadeRings(opts, field, rings)
+}
+
+// ShadeMultiPolygon hatches each polygon of the multipolygon with a density that follows the
+// field. Holes are left empty.
+func ShadeMultiPolygon(opts ShadeOptions, field Field, m polygon.MultiPolygon) []*path.Path {
+	return ShadeRings(opts, field, m.Rings())
+}
+
+// ShadeBounds hatches the rectangular area of the bounds. Use it with ImageField to plot an
+// image.
+func ShadeBounds(opts ShadeOptions, field Field, b bounds.Bounds) []*path.Path {
+	ring := []point.Point{*b.TopLeft(), *b.TopRight(), *b.BottomRight(), *b.BottomLeft()}
+	return ShadeRings(opts, field, [][]point.Point{ring})
+}
+
+// ShadeRings hatches the area enclosed by the rings, using the even-odd rule, with a density
+// that follows the field.
+func ShadeRings(opts ShadeOptions, field Field, rings [][]point.Point) []*path.Path {
+	if opts.Spacing <= 0 {
+		return nil
+	}
+	tone := opts.tone()
+	angles := opts.angles()
+	levels := opts.levels()
+	thresholds := ditherThresholds(levels)
+	step := opts.resolution()
+
+	var ret []*path.Path
+	for layer, angle := range angles {
+		rotated, ys, indices := scanlines(rings, angle, opts.Spacing, 0)
+		rows := scan(rotated, ys)
+		// darkness returns how far through this layer's band of tone the point is
+		darkness := func(x, y float64) float64 {
+			p := point.NewPoint(x, y).Rotate(angle)
+			return tone(field(p.X, p.Y))*float64(len(angles)) - float64(layer)
+		}
+
+		var lines [][]point.Point
+		for i, row := range rows {
+			threshold := thresholds[util.Mod(indices[i], levels)]
+			for _, s := range row {
+				for _, dash := range dashes(s, ys[i], step, threshold, darkness) {
+					if dash[1]-dash[0] >= opts.MinLength {
+						lines = append(lines, []point.Point{point.NewPoint(dash[0], ys[i]), point.NewPoint(dash[1], ys[i])})
+					}
+				}
+			}
+		}
+		ret = append(ret, toPaths(lines, angle)...)
+	}
+	return ret
+}
+
+// dashes returns the x ranges of the span where darkness is greater than the threshold.
+// The ends of each dash are interpolated between samples.
+func dashes(s span, y, step, threshold float64, darkness func(x, y float64) float64) [][2]float64 {
+	var ret [][2]float64
+	n := int(math.Ceil((s.x1 - s.x0) / step))
+	if n < 1 {
+		n = 1
+	}
+	prevX := s.x0
+	prevV := darkness(prevX, y) - threshold
+	start := math.NaN()
+	if prevV > 0 {
+		start = prevX
+	}
+	for i := 1; i <= n; i++ {
+		x := s.x0 + (s.x1-s.x0)*float64(i)/float64(n)
+		v := darkness(x, y) - threshold
+		if (v > 0) != (prevV > 0) {
+			cross := prevX + (x-prevX)*prevV/(prevV-v)
+			if v > 0 {
+				start = cross
+			} else {
+				ret = append(ret, [2]float64{start, cross})
+				start = math.NaN()
+			}
+		}
+		prevX, prevV = x, v
+	}
+	if !math.IsNaN(start) {
+		ret = append(ret, [2]float64{start, s.x1})
+	}
+	return ret
+}
+
+// ditherThresholds returns a threshold for each of n lines. The thresholds are ordered so that
+// the lines drawn at any tone are spread as evenly as possible.
+func ditherThresholds(n int) []float64 {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	// Sort by the bit reversed index, the base 2 van der Corput sequence
+	sort.SliceStable(order, func(a, b int) bool {
+		return vanDerCorput(order[a]) < vanDerCorput(order[b])
+	})
+	ret := make([]float64, n)
+	for rank, i := range order {
+		ret[i] = float64(rank) / float64(n)
+	}
+	return ret
+}
+
+func vanDerCorput(i int) float64 {
+	ret := 0.
+	denom := 1.
+	for i > 0 {
+		denom *= 2
+		ret += float64(i%2) / denom
+		i /= 2
+	}
+	return ret
+}
@@ -0,0 +1,78 @@
+package hatch
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func constant(v float64) Field {
+	return func(x, y float64) float64 {
+		return v
+	}
+}
+
+func TestShade(t *testing.T) {
+	rect := polygon.NewRectangle(0, 0, 10, 10)
+
+	t.Run("layers are added as the tone darkens", func(t *testing.T) {
+		opts := ShadeOptions{Spacing: 2, Angles: []float64{0, math.Pi / 2}}
+		assert.Equal(t, 0, len(Shade(opts, constant(0), rect.Polygon)))
+		assert.Equal(t, 4, len(Shade(opts, constant(0.4), rect.Polygon)))
+		assert.Equal(t, 8, len(Shade(opts, constant(0.6), rect.Polygon)))
+	})
+
+	t.Run("levels thin out the lines", func(t *testing.T) {
+		opts := ShadeOptions{Spacing: 1, Levels: 4}
+		assert.Equal(t, 9, len(Shade(opts, constant(1), rect.Polygon)))
+		half := Shade(opts, constant(0.5), rect.Polygon)
+		assert.Equal(t, 4, len(half))
+		// Lines that remain are evenly spread
+		for i := 1; i < len(half); i++ {
+			assert.Equal(t, 2., half[i].Segments[0].Y-half[i-1].Segments[0].Y)
+		}
+	})
+
+	t.Run("dashes follow the field", func(t *testing.T) {
+		gradient := func(x, y float64) float64 {
+			return x / 10
+		}
+		lines := Shade(ShadeOptions{Spacing: 2, Angles: []float64{0, math.Pi / 2}}, gradient, rect.Polygon)
+		for _, l := range lines[:4] {
+			assert.Equal(t, 0., l.Segments[0].X)
+			assert.Equal(t, 10., l.Segments[1].X)
+		}
+		// The second layer is only drawn where x > 5
+		for _, l := range lines[4:] {
+			assert.True(t, l.Segments[0].X > 5)
+		}
+	})
+
+	t.Run("band tone", func(t *testing.T) {
+		tone := BandTone(3)
+		assert.Equal(t, 0., tone(0.2))
+		assert.Equal(t, 0.5, tone(0.5))
+		assert.Equal(t, 1., tone(0.9))
+	})
+}
+
+func TestImageField(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 2, 1))
+	img.SetGray(0, 0, color.Gray{Y: 0})
+	img.SetGray(1, 0, color.Gray{Y: 255})
+	field := ImageField(img, bounds.NewBounds(0, 100, 50, 0))
+	assert.Equal(t, 1., field(25, 25))
+	assert.Equal(t, 0., field(75, 25))
+	assert.Equal(t, 0., field(150, 25))
+
+	lines := ShadeBounds(ShadeOptions{Spacing: 10}, field, bounds.NewBounds(0, 100, 50, 0))
+	assert.Equal(t, 4, len(lines))
+	for _, l := range lines {
+		assert.InDelta(t, 50., l.Segments[1].X, 25)
+	}
+}
@@ -64,6 +64,42 @@ func GetIntersection(x1, y1, x2, y2, x3, y3, x4, y4 float64) (x, y float64, ok b
 	return x, y, true
 }
 
+// GetIntersectionParams returns the parameters t and u along the segments
+// (x1, y1)-(x2, y2) and (x3, y3)-(x4, y4) at which they intersect. Both values
+// are in the range 0 to 1 when ok is true. Parallel segments never intersect.
+func GetIntersectionParams(x1, y1, x2, y2, x3, y3, x4, y4 float64) (t, u float64, ok bool) {
+	denom := (x2-x1)*(y4-y3) - (y2-y1)*(x4-x3)
+	if denom == 0 {
+		return 0, 0, false
+	}
+	t = ((x3-x1)*(y4-y3) - (y3-y1)*(x4-x3)) / denom
+	u = ((x3-x1)*(y2-y1) - (y3-y1)*(x2-x1)) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return 0, 0, false
+	}
+	return t, u, true
+}
+
+// ClosestPoint returns the point on the segment (x1, y1)-(x2, y2) that is closest
+// to (px, py) along with its parameter t in the range 0 to 1.
+func ClosestPoint(x1, y1, x2, y2, px, py float64) (x, y, t float64) {
+	dx := x2 - x1
+	dy := y2 - y1
+	lenSq := dx*dx + dy*dy
+	if lenSq == 0 {
+		return x1, y1, 0
+	}
+	t = ((px-x1)*dx + (py-y1)*dy) / lenSq
+	t = math.Max(0, math.Min(1, t))
+	return x1 + t*dx, y1 + t*dy, t
+}
+
+// DistanceToPoint returns the distance from (px, py) to the segment (x1, y1)-(x2, y2)
+func DistanceToPoint(x1, y1, x2, y2, px, py float64) float64 {
+	x, y, _ := ClosestPoint(x1, y1, x2, y2, px, py)
+	return math.Hypot(px-x, py-y)
+}
+
 // Standard form
 // Ax + By = C
 type Line struct {
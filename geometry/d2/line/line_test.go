@@ -61,3 +61,36 @@ func TestLineIntersection(t *testing.T) {
 		assert.True(t, ok)
 	})
 }
+
+func TestGetIntersectionParams(t *testing.T) {
+	t.Run("crossing segments", func(t *testing.T) {
+		s, u, ok := GetIntersectionParams(0, 0, 2, 2, 0, 2, 2, 0)
+		assert.True(t, ok)
+		assert.Equal(t, 0.5, s)
+		assert.Equal(t, 0.5, u)
+	})
+
+	t.Run("segments do not reach", func(t *testing.T) {
+		_, _, ok := GetIntersectionParams(0, 0, 1, 0, 2, -1, 2, 1)
+		assert.False(t, ok)
+	})
+
+	t.Run("parallel segments", func(t *testing.T) {
+		_, _, ok := GetIntersectionParams(0, 0, 1, 0, 0, 1, 1, 1)
+		assert.False(t, ok)
+	})
+}
+
+func TestClosestPoint(t *testing.T) {
+	x, y, s := ClosestPoint(0, 0, 10, 0, 5, 5)
+	assert.Equal(t, 5., x)
+	assert.Equal(t, 0., y)
+	assert.Equal(t, 0.5, s)
+
+	x, y, s = ClosestPoint(0, 0, 10, 0, -5, 5)
+	assert.Equal(t, 0., x)
+	assert.Equal(t, 0., y)
+	assert.Equal(t, 0., s)
+
+	assert.Equal(t, 5., DistanceToPoint(0, 0, 10, 0, 13, 4))
+}
@@ -0,0 +1,107 @@
+package occlude
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/clip"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+const DefaultTolerance = 0.1
+
+// A Scene collects paths in the order they are drawn, back to front. Closed shapes hide the
+// parts of the paths added before them that they cover, so the result can be plotted without
+// the hidden strokes showing through.
+type Scene struct {
+	// Tolerance is the maximum distance between a curved shape and the polygon used to mask
+	// with it. Defaults to DefaultTolerance.
+	Tolerance float64
+	paths     []*path.Path
+}
+
+func NewScene() *Scene {
+	return &Scene{}
+}
+
+func (s *Scene) tolerance() float64 {
+	if s.Tolerance <= 0 {
+		return DefaultTolerance
+	}
+	return s.Tolerance
+}
+
+// Add draws the path in front of everything already in the scene. If the path is closed the
+// area it encloses hides the paths behind it. Details such as hatching that belong to the shape
+// are drawn with it and are not hidden by it.
+func (s *Scene) Add(p *path.Path, details ...*path.Path) {
+	if p.Closed {
+		s.mask([][]point.Point{s.ring(p)})
+	}
+	s.paths = append(s.paths, p)
+	s.paths = append(s.paths, details...)
+}
+
+// AddPolygon draws the polygon and its holes in front of everything already in the scene.
+// Paths behind the polygon are hidden except where they show through the holes.
+func (s *Scene) AddPolygon(poly *polygon.Polygon, holes ...*polygon.Polygon) {
+	rings := make([][]point.Point, 0, len(holes)+1)
+	rings = append(rings, s.ring(poly.Path))
+	for _, hole := range holes {
+		rings = append(rings, s.ring(hole.Path))
+	}
+	s.mask(rings)
+	s.paths = append(s.paths, poly.Path)
+	for _, hole := range holes {
+		s.paths = append(s.paths, hole.Path)
+	}
+}
+
+// Paths returns the visible parts of the paths in the order they were added
+func (s *Scene) Paths() []*path.Path {
+	return s.paths
+}
+
+// Occlude treats the paths as drawn in order, back to front, and returns the parts of them that
+// are not hidden by a closed path drawn later.
+func Occlude(paths []*path.Path) []*path.Path {
+	s := NewScene()
+	for _, p := range paths {
+		s.Add(p)
+	}
+	return s.Paths()
+}
+
+// mask removes the parts of the paths in the scene that are inside the rings
+func (s *Scene) mask(rings [][]point.Point) {
+	area := ringBounds(rings)
+	ret := make([]*path.Path, 0, len(s.paths))
+	for _, p := range s.paths {
+		if len(p.Segments) == 0 || !area.OverlapsInclusive(*p.GetBounds()) {
+			ret = append(ret, p)
+			continue
+		}
+		ret = append(ret, clip.ClipPathToRings(rings, p, true)...)
+	}
+	s.paths = ret
+}
+
+// ring returns the vertices of a polygon within the tolerance of the closed path
+func (s *Scene) ring(p *path.Path) []point.Point {
+	return path.FromSegments(p.Segments, true).Flatten(s.tolerance()).Points()
+}
+
+func ringBounds(rings [][]point.Point) bounds.Bounds {
+	ret := bounds.NewBounds(math.Inf(1), math.Inf(-1), math.Inf(-1), math.Inf(1))
+	for _, ring := range rings {
+		for _, p := range ring {
+			ret.Top = math.Min(ret.Top, p.Y)
+			ret.Right = math.Max(ret.Right, p.X)
+			ret.Bottom = math.Max(ret.Bottom, p.Y)
+			ret.Left = math.Min(ret.Left, p.X)
+		}
+	}
+	return ret
+}
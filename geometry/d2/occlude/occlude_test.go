@@ -0,0 +1,55 @@
+package occlude
+
+import (
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScene(t *testing.T) {
+	t.Run("stacked cards", func(t *testing.T) {
+		s := NewScene()
+		s.Add(polygon.NewRectangle(0, 0, 10, 10).Path)
+		s.Add(polygon.NewRectangle(5, 5, 10, 10).Path)
+		paths := s.Paths()
+		assert.Equal(t, 2, len(paths))
+		assert.Equal(t, []point.Point{{X: 5, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 5}}, paths[0].Points())
+		assert.True(t, paths[1].Closed)
+	})
+
+	t.Run("details are not hidden by their shape", func(t *testing.T) {
+		s := NewScene()
+		s.Add(path.NewOpenPath([]float64{-5, 5, 15, 5}))
+		s.Add(polygon.NewRectangle(0, 0, 10, 10).Path, path.NewOpenPath([]float64{0, 2, 10, 2}))
+		paths := s.Paths()
+		assert.Equal(t, 4, len(paths))
+		assert.Equal(t, []point.Point{{X: -5, Y: 5}, {X: 0, Y: 5}}, paths[0].Points())
+		assert.Equal(t, []point.Point{{X: 10, Y: 5}, {X: 15, Y: 5}}, paths[1].Points())
+		assert.Equal(t, []point.Point{{X: 0, Y: 2}, {X: 10, Y: 2}}, paths[3].Points())
+	})
+
+	t.Run("holes show what is behind", func(t *testing.T) {
+		s := NewScene()
+		s.Add(path.NewOpenPath([]float64{-5, 5, 15, 5}))
+		s.AddPolygon(polygon.NewRectangle(0, 0, 10, 10).Polygon, polygon.NewRectangle(4, 4, 2, 2).Polygon)
+		paths := s.Paths()
+		assert.Equal(t, 5, len(paths))
+		assert.Equal(t, []point.Point{{X: 4, Y: 5}, {X: 6, Y: 5}}, paths[1].Points())
+	})
+
+	t.Run("curved shapes", func(t *testing.T) {
+		circle := path.FromSegments([]path.Segment{
+			path.NewCubicBezierSegment(point.NewPoint(10, 0), point.NewPoint(10, 5.5228), point.NewPoint(5.5228, 10)),
+			path.NewCubicBezierSegment(point.NewPoint(0, 10), point.NewPoint(-5.5228, 10), point.NewPoint(-10, 5.5228)),
+			path.NewCubicBezierSegment(point.NewPoint(-10, 0), point.NewPoint(-10, -5.5228), point.NewPoint(-5.5228, -10)),
+			path.NewCubicBezierSegment(point.NewPoint(0, -10), point.NewPoint(5.5228, -10), point.NewPoint(10, -5.5228)),
+		}, true)
+		paths := Occlude([]*path.Path{path.NewOpenPath([]float64{-20, 0, 20, 0}), circle})
+		assert.Equal(t, 3, len(paths))
+		assert.InDelta(t, -10, paths[0].Segments[1].X, 0.1)
+		assert.InDelta(t, 10, paths[1].Segments[0].X, 0.1)
+	})
+}
@@ -0,0 +1,277 @@
+package offset
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+// Join is the shape used to connect offset segments on the outside of a corner.
+type Join int
+
+const (
+	JoinMiter Join = iota
+	JoinRound
+	JoinBevel
+)
+
+// Cap is the shape used to close the ends of a stroked open path.
+type Cap int
+
+const (
+	CapButt Cap = iota
+	CapRound
+	CapSquare
+)
+
+const (
+	DefaultMiterLimit = 4.
+	DefaultTolerance  = 0.1
+)
+
+// Options control how corners, ends and curves are offset.
+// The zero value uses miter joins, butt caps and the default limits.
+type Options struct {
+	Join Join
+	Cap  Cap
+	// MiterLimit is the maximum ratio of the miter length to the offset distance.
+	// Corners that exceed it are beveled.
+	MiterLimit float64
+	// Tolerance is the maximum distance the returned curves may deviate from the true offset.
+	Tolerance float64
+}
+
+func (o Options) miterLimit() float64 {
+	if o.MiterLimit <= 0 {
+		return DefaultMiterLimit
+	}
+	return o.MiterLimit
+}
+
+func (o Options) tolerance() float64 {
+	if o.Tolerance <= 0 {
+		return DefaultTolerance
+	}
+	return o.Tolerance
+}
+
+// Path offsets a path by the distance d.
+// Closed paths are offset outward for positive d and inward for negative d regardless of
+// their orientation. Open paths are offset to the left of the direction of travel
+// (the side of point.Point.Normal) for positive d and to the right for negative d.
+// Parts of the raw offset that fold back over themselves are removed, so a path can split
+// into several paths or vanish entirely. Curves are preserved as cubic beziers wherever
+// they are not trimmed.
+func Path(p *path.Path, d float64, opts Options) []*path.Path {
+	return offsetPath(p, d, opts, true)
+}
+
+// Polygon offsets the polygon outward for positive d and inward for negative d.
+// Corners are joined with straight lines so the results are polygons. Insetting can split
+// a polygon into several pieces, and outsetting a concave polygon can create holes, so every
+// resulting ring is returned as its own polygon.
+func Polygon(poly *polygon.Polygon, d float64, opts Options) []*polygon.Polygon {
+	paths := offsetPath(poly.Path, d, opts, false)
+	ret := make([]*polygon.Polygon, 0, len(paths))
+	for _, p := range paths {
+		if !p.Closed || len(p.Segments) < 3 {
+			continue
+		}
+		ret = append(ret, &polygon.Polygon{Path: p})
+	}
+	return ret
+}
+
+// Stroke returns the outline of the path drawn with a pen of the given width.
+// Open paths are closed off with the caps in opts. Closed paths produce an outer and an
+// inner contour.
+func Stroke(p *path.Path, width float64, opts Options) []*path.Path {
+	tol := opts.tolerance()
+	src := sourcePieces(p, tol)
+	if len(src) == 0 || width <= 0 {
+		return nil
+	}
+	r := width / 2
+	flat := flattenSource(src, p.Closed, tol)
+
+	if p.Closed {
+		ret := trim(offsetPieces(src, r*orientation(flat), true, opts), true, flat, true, r, tol, true)
+		return append(ret, trim(offsetPieces(src, -r*orientation(flat), true, opts), true, flat, true, r, tol, true)...)
+	}
+
+	reversed := reversePieces(src)
+	first := src[0]
+	last := src[len(src)-1]
+
+	var raw []piece
+	raw = append(raw, offsetPieces(src, r, false, opts)...)
+	raw = append(raw, capPieces(last.end(), last.endTangent(), r, opts.Cap)...)
+	raw = append(raw, offsetPieces(reversed, r, false, opts)...)
+	raw = append(raw, capPieces(first.start(), first.startTangent().ScalarMult(-1), r, opts.Cap)...)
+	return trim(raw, true, flat, false, r, tol, true)
+}
+
+// Strokes fills a stroke of the given width with parallel copies of the path spaced no more
+// than spacing apart. Plotters use this to draw lines thicker than the pen.
+func Strokes(p *path.Path, width, spacing float64, opts Options) []*path.Path {
+	if width <= 0 || spacing <= 0 {
+		return []*path.Path{p}
+	}
+	n := int(math.Ceil(width/spacing)) + 1
+	ret := make([]*path.Path, 0, n)
+	for i := 0; i < n; i++ {
+		d := -width/2 + float64(i)*width/float64(n-1)
+		if d == 0 {
+			ret = append(ret, p)
+			continue
+		}
+		ret = append(ret, Path(p, d, opts)...)
+	}
+	return ret
+}
+
+func offsetPath(p *path.Path, d float64, opts Options, keepCurves bool) []*path.Path {
+	tol := opts.tolerance()
+	src := sourcePieces(p, tol)
+	if len(src) == 0 {
+		return nil
+	}
+	if d == 0 {
+		return []*path.Path{p}
+	}
+	flat := flattenSource(src, p.Closed, tol)
+	if p.Closed {
+		d *= orientation(flat)
+	}
+	raw := offsetPieces(src, d, p.Closed, opts)
+	return trim(raw, p.Closed, flat, p.Closed, math.Abs(d), tol, keepCurves)
+}
+
+// offsetPieces creates the untrimmed offset of the pieces, joining neighbouring pieces at corners.
+func offsetPieces(src []piece, d float64, closed bool, opts Options) []piece {
+	tol := opts.tolerance()
+	var ret []piece
+	var first []piece
+	for i, pc := range src {
+		offs := pc.offset(d, tol)
+		if i == 0 {
+			first = offs
+		} else {
+			prev := src[i-1]
+			ret = append(ret, joinPieces(pc.start(), prev.endTangent(), pc.startTangent(), ret[len(ret)-1].end(), offs[0].start(), d, opts)...)
+		}
+		ret = append(ret, offs...)
+	}
+	if closed {
+		last := src[len(src)-1]
+		ret = append(ret, joinPieces(src[0].start(), last.endTangent(), src[0].startTangent(), ret[len(ret)-1].end(), first[0].start(), d, opts)...)
+	}
+	return ret
+}
+
+// joinPieces connects the offset ending at a to the offset starting at b around the vertex v.
+// tin and tout are the unit tangents of the source on either side of v.
+func joinPieces(v, tin, tout, a, b point.Point, d float64, opts Options) []piece {
+	if a.EqualsWithTolerance(b, 1e-9) {
+		return nil
+	}
+	side := math.Copysign(1, d)
+	cross := tin.X*tout.Y - tin.Y*tout.X
+	dot := tin.Dot(tout)
+	reversal := math.Abs(cross) < 1e-9 && dot < 0
+	if side*cross <= 0 && !reversal {
+		// Inside of the corner. Connecting through the vertex guarantees that the fold
+		// is trimmed. Nearly smooth corners are joined directly.
+		if a.Distance(b) < opts.tolerance()/100 {
+			return []piece{linePiece(a, b)}
+		}
+		return []piece{linePiece(a, v), linePiece(v, b)}
+	}
+
+	r := math.Abs(d)
+	na := tin.Normal().ScalarMult(side)
+	nb := tout.Normal().ScalarMult(side)
+	ret := []piece{linePiece(a, b)}
+	switch opts.Join {
+	case JoinRound:
+		sweep := math.Atan2(na.X*nb.Y-na.Y*nb.X, na.Dot(nb))
+		if reversal {
+			sweep = math.Copysign(math.Pi, na.X*tin.Y-na.Y*tin.X)
+		}
+		ret = arcPieces(v, r, na.Direction(), sweep)
+	case JoinMiter:
+		cosHalf := math.Sqrt((1 + na.Dot(nb)) / 2)
+		if !reversal && 1/cosHalf <= opts.miterLimit() {
+			m := v.AddPoint(na.AddPoint(nb).ScalarMult(r / (1 + na.Dot(nb))))
+			ret = []piece{linePiece(a, m), linePiece(m, b)}
+		}
+	}
+	for i := range ret {
+		ret[i].outer = true
+	}
+	return ret
+}
+
+// capPieces closes the end of a stroke at e where the path is travelling in the direction t.
+// The cap runs from the left side of the stroke to the right side.
+func capPieces(e, t point.Point, r float64, c Cap) []piece {
+	n := t.Normal().ScalarMult(r)
+	left := e.AddPoint(n)
+	right := e.SubtractPoint(n)
+	var ret []piece
+	switch c {
+	case CapRound:
+		ret = arcPieces(e, r, n.Direction(), math.Pi)
+	case CapSquare:
+		ext := t.ScalarMult(r)
+		ret = []piece{
+			linePiece(left, left.AddPoint(ext)),
+			linePiece(left.AddPoint(ext), right.AddPoint(ext)),
+			linePiece(right.AddPoint(ext), right),
+		}
+	default:
+		ret = []piece{linePiece(left, right)}
+	}
+	for i := range ret {
+		ret[i].outer = true
+	}
+	return ret
+}
+
+// arcPieces approximates a circular arc with cubic beziers of at most a quarter turn each.
+func arcPieces(center point.Point, r, start, sweep float64) []piece {
+	n := int(math.Ceil(math.Abs(sweep) / (math.Pi / 2)))
+	if n == 0 {
+		return nil
+	}
+	step := sweep / float64(n)
+	k := 4. / 3 * math.Tan(step/4)
+	ret := make([]piece, n)
+	for i := 0; i < n; i++ {
+		a0 := start + float64(i)*step
+		a1 := a0 + step
+		p0 := center.AddPoint(point.NewPointFromAngle(a0, r))
+		p3 := center.AddPoint(point.NewPointFromAngle(a1, r))
+		p1 := p0.AddPoint(point.NewPointFromAngle(a0+math.Pi/2, r*k))
+		p2 := p3.AddPoint(point.NewPointFromAngle(a1+math.Pi/2, -r*k))
+		ret[i] = cubicPiece(p0, p1, p2, p3)
+	}
+	return ret
+}
+
+// orientation returns 1 when the ring's vertices are ordered so that point.Point.Normal
+// points out of the ring and -1 otherwise.
+func orientation(ring []point.Point) float64 {
+	area := 0.
+	for i := range ring {
+		a := ring[i]
+		b := ring[(i+1)%len(ring)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	if area < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,170 @@
+package offset
+
+import (
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func square() *polygon.Polygon {
+	return polygon.NewPolygon(polygon.Points{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}})
+}
+
+func TestPolygon(t *testing.T) {
+	t.Run("inset", func(t *testing.T) {
+		polys := Polygon(square(), -2, Options{})
+		assert.Equal(t, 1, len(polys))
+		assert.Equal(t, []point.Point{{X: 2, Y: 2}, {X: 8, Y: 2}, {X: 8, Y: 8}, {X: 2, Y: 8}}, polys[0].Points())
+	})
+
+	t.Run("outset", func(t *testing.T) {
+		polys := Polygon(square(), 2, Options{})
+		assert.Equal(t, 1, len(polys))
+		b := polys[0].GetBounds()
+		assert.Equal(t, -2., b.Left)
+		assert.Equal(t, -2., b.Top)
+		assert.Equal(t, 12., b.Right)
+		assert.Equal(t, 12., b.Bottom)
+	})
+
+	t.Run("orientation does not change direction", func(t *testing.T) {
+		reversed := polygon.NewPolygon(polygon.Points{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}})
+		polys := Polygon(reversed, -2, Options{})
+		assert.Equal(t, 1, len(polys))
+		assert.Equal(t, 6., polys[0].GetBounds().Width())
+	})
+
+	t.Run("inset vanishes", func(t *testing.T) {
+		assert.Equal(t, 0, len(Polygon(square(), -6, Options{})))
+		assert.Equal(t, 0, len(Polygon(polygon.NewNgon(30, 0, 0, 10), -11, Options{})))
+	})
+
+	t.Run("inset of a many sided polygon is one ring", func(t *testing.T) {
+		for _, sides := range []int{32, 128} {
+			polys := Polygon(polygon.NewNgon(sides, 0, 0, 20), -12, Options{})
+			assert.Equal(t, 1, len(polys), sides)
+			assert.Equal(t, sides, len(polys[0].Segments), sides)
+		}
+	})
+
+	t.Run("inset of a star stays inside", func(t *testing.T) {
+		star := polygon.NewStar(100, 100, 100, 50, 5)
+		polys := Polygon(star, -10, Options{})
+		assert.Equal(t, 1, len(polys))
+		assert.Equal(t, 10, len(polys[0].Segments))
+		for _, p := range polys[0].Points() {
+			assert.True(t, star.ContainsPoint(p.X, p.Y))
+		}
+	})
+}
+
+func TestPath(t *testing.T) {
+	t.Run("open path sides", func(t *testing.T) {
+		p := path.NewOpenPath([]float64{0, 0, 10, 0, 10, 10})
+		left := Path(p, 2, Options{Join: JoinBevel})
+		assert.Equal(t, 1, len(left))
+		assert.Equal(t, []point.Point{{X: 0, Y: -2}, {X: 10, Y: -2}, {X: 12, Y: 0}, {X: 12, Y: 10}}, left[0].Points())
+
+		right := Path(p, -2, Options{})
+		assert.Equal(t, 1, len(right))
+		assert.Equal(t, []point.Point{{X: 0, Y: 2}, {X: 8, Y: 2}, {X: 8, Y: 10}}, right[0].Points())
+	})
+
+	t.Run("round joins are curves", func(t *testing.T) {
+		paths := Path(square().Path, 2, Options{Join: JoinRound})
+		assert.Equal(t, 1, len(paths))
+		curves := 0
+		for _, s := range paths[0].Segments {
+			if s.Curve != nil {
+				curves++
+			}
+		}
+		assert.Equal(t, 4, curves)
+	})
+
+	t.Run("curves stay within tolerance", func(t *testing.T) {
+		c1, c2, c3 := point.NewPoint(25, 50), point.NewPoint(75, 50), point.NewPoint(100, 100)
+		p := path.FromSegments([]path.Segment{
+			path.NewCubicBezierSegment(point.NewPoint(0, 0), c1, c2),
+			path.NewSegment(c3.X, c3.Y),
+		}, false)
+		opts := Options{Tolerance: 0.01}
+		paths := Path(p, 5, opts)
+		assert.Equal(t, 1, len(paths))
+		src := flattenSource(sourcePieces(p, opts.Tolerance), false, opts.Tolerance)
+		for i := 0.; i < float64(len(paths[0].Segments)-1); i += 0.05 {
+			x, y := paths[0].Interpolate(i)
+			assert.InDelta(t, 5., distanceTo(src, false, point.NewPoint(x, y)), 0.02)
+		}
+	})
+}
+
+func TestStroke(t *testing.T) {
+	t.Run("closed path has inner and outer contours", func(t *testing.T) {
+		paths := Stroke(square().Path, 2, Options{})
+		assert.Equal(t, 2, len(paths))
+		assert.Equal(t, 12., paths[0].GetBounds().Width())
+		assert.Equal(t, 8., paths[1].GetBounds().Width())
+	})
+
+	t.Run("crossing path encloses a hole", func(t *testing.T) {
+		p := path.NewOpenPath([]float64{0, 0, 20, 20, 20, 0, 0, 20})
+		paths := Stroke(p, 4, Options{})
+		assert.Equal(t, 2, len(paths))
+	})
+
+	t.Run("caps", func(t *testing.T) {
+		p := path.NewOpenPath([]float64{0, 0, 10, 0})
+		butt := Stroke(p, 2, Options{Cap: CapButt})
+		assert.Equal(t, 1, len(butt))
+		assert.Equal(t, 10., butt[0].GetBounds().Width())
+
+		square := Stroke(p, 2, Options{Cap: CapSquare})
+		assert.Equal(t, 1, len(square))
+		assert.Equal(t, 12., square[0].GetBounds().Width())
+	})
+}
+
+func TestStrokes(t *testing.T) {
+	p := path.NewOpenPath([]float64{0, 0, 10, 0})
+	paths := Strokes(p, 2, 0.5, Options{})
+	assert.Equal(t, 5, len(paths))
+	assert.Equal(t, 1., paths[0].Segments[0].Y)
+	assert.Equal(t, -1., paths[4].Segments[0].Y)
+}
+
+func TestArc(t *testing.T) {
+	// A semicircle of radius 50 over the center 50, 0
+	semicircle := path.FromSegments([]path.Segment{
+		{Point: point.NewPoint(0, 0), Curve: path.NewArc(50, 50, 0, false, true)},
+		path.NewSegment(100, 0),
+	}, false)
+	center := point.NewPoint(50, 0)
+	tol := Options{}.tolerance()
+
+	t.Run("offset follows the curve", func(t *testing.T) {
+		paths := Path(semicircle, 5, Options{})
+		assert.Equal(t, 1, len(paths))
+		assert.Greater(t, len(paths[0].Segments), 2)
+		for _, p := range paths[0].Points() {
+			assert.InDelta(t, 55, p.Distance(center), tol)
+		}
+		assert.InDelta(t, -55, paths[0].GetBounds().Top, tol)
+	})
+
+	t.Run("stroke surrounds the curve", func(t *testing.T) {
+		paths := Stroke(semicircle, 10, Options{Cap: CapButt})
+		assert.Equal(t, 1, len(paths))
+		for _, p := range paths[0].Points() {
+			d := p.Distance(center)
+			assert.True(t, d > 45-tol && d < 55+tol, d)
+		}
+		b := paths[0].GetBounds()
+		assert.InDelta(t, -55, b.Top, tol)
+		assert.InDelta(t, -5, b.Left, tol)
+		assert.InDelta(t, 105, b.Right, tol)
+	})
+}
@@ -0,0 +1,216 @@
+package offset
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bezier"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// Maximum number of times a cubic is subdivided while fitting its offset.
+const maxDepth = 8
+
+// A piece is a single line or cubic bezier. Lines keep their end points in p[0] and p[3].
+type piece struct {
+	p     [4]point.Point
+	cubic bool
+	// outer pieces are the caps and outside corner joins. They are allowed to come closer
+	// to the source than the offset distance.
+	outer bool
+}
+
+func linePiece(a, b point.Point) piece {
+	return piece{p: [4]point.Point{a, a, b, b}}
+}
+
+func cubicPiece(p0, p1, p2, p3 point.Point) piece {
+	return piece{p: [4]point.Point{p0, p1, p2, p3}, cubic: true}
+}
+
+func (pc piece) start() point.Point {
+	return pc.p[0]
+}
+
+func (pc piece) end() point.Point {
+	return pc.p[3]
+}
+
+func (pc piece) at(t float64) point.Point {
+	if !pc.cubic {
+		return pc.p[0].AddPoint(pc.p[3].SubtractPoint(pc.p[0]).ScalarMult(t))
+	}
+	return point.NewPoint(bezier.Polynomial(pc.p[0], pc.p[1], pc.p[2], pc.p[3], t))
+}
+
+// startTangent returns the unit direction of travel at the start of the piece
+func (pc piece) startTangent() point.Point {
+	for i := 1; i < 4; i++ {
+		if d := pc.p[i].SubtractPoint(pc.p[0]); d.Magnitude() > 1e-12 {
+			return d.Normalize()
+		}
+	}
+	return point.NewPoint(1, 0)
+}
+
+// endTangent returns the unit direction of travel at the end of the piece
+func (pc piece) endTangent() point.Point {
+	for i := 2; i >= 0; i-- {
+		if d := pc.p[3].SubtractPoint(pc.p[i]); d.Magnitude() > 1e-12 {
+			return d.Normalize()
+		}
+	}
+	return point.NewPoint(1, 0)
+}
+
+func (pc piece) reverse() piece {
+	pc.p[0], pc.p[1], pc.p[2], pc.p[3] = pc.p[3], pc.p[2], pc.p[1], pc.p[0]
+	return pc
+}
+
+// offset returns pieces that approximate the piece moved d along its normal
+func (pc piece) offset(d, tolerance float64) []piece {
+	if !pc.cubic {
+		n := pc.startTangent().Normal().ScalarMult(d)
+		return []piece{linePiece(pc.p[0].AddPoint(n), pc.p[3].AddPoint(n))}
+	}
+	return offsetCubic(pc, d, tolerance, 0)
+}
+
+// offsetCubic fits a cubic to the offset of pc. The end points and tangents of the fit match the
+// true offset and the handle lengths are chosen by least squares. When the fit deviates from the
+// true offset by more than the tolerance the curve is subdivided.
+func offsetCubic(pc piece, d, tolerance float64, depth int) []piece {
+	t0 := pc.startTangent()
+	t1 := pc.endTangent()
+	q0 := pc.p[0].AddPoint(t0.Normal().ScalarMult(d))
+	q3 := pc.p[3].AddPoint(t1.Normal().ScalarMult(d))
+
+	samples := 8
+	ts := make([]float64, 0, samples)
+	targets := make([]point.Point, 0, samples)
+	for i := 1; i < samples; i++ {
+		t := float64(i) / float64(samples)
+		dx, dy := bezier.Derivative(pc.p[0], pc.p[1], pc.p[2], pc.p[3], t)
+		tangent := point.NewPoint(dx, dy)
+		if tangent.Magnitude() < 1e-12 {
+			continue
+		}
+		ts = append(ts, t)
+		targets = append(targets, pc.at(t).AddPoint(tangent.Normalize().Normal().ScalarMult(d)))
+	}
+
+	// Solve for the handle lengths a and b in q1 = q0 + a*t0, q2 = q3 - b*t1
+	var c00, c01, c11, x0, x1 float64
+	for i, t := range ts {
+		mt := 1 - t
+		b0 := mt * mt * mt
+		b1 := 3 * mt * mt * t
+		b2 := 3 * mt * t * t
+		b3 := t * t * t
+		a1 := t0.ScalarMult(b1)
+		a2 := t1.ScalarMult(-b2)
+		rest := targets[i].SubtractPoint(q0.ScalarMult(b0 + b1)).SubtractPoint(q3.ScalarMult(b2 + b3))
+		c00 += a1.Dot(a1)
+		c01 += a1.Dot(a2)
+		c11 += a2.Dot(a2)
+		x0 += a1.Dot(rest)
+		x1 += a2.Dot(rest)
+	}
+	chord := q0.Distance(q3)
+	a, b := chord/3, chord/3
+	if det := c00*c11 - c01*c01; math.Abs(det) > 1e-12 {
+		fa := (x0*c11 - c01*x1) / det
+		fb := (c00*x1 - c01*x0) / det
+		if fa > 0 && fb > 0 {
+			a, b = fa, fb
+		}
+	}
+	fit := cubicPiece(q0, q0.AddPoint(t0.ScalarMult(a)), q3.SubtractPoint(t1.ScalarMult(b)), q3)
+
+	if depth < maxDepth {
+		for i, t := range ts {
+			if fit.at(t).Distance(targets[i]) > tolerance {
+				left, right := path.Subdivide(pc.p[:], 0.5)
+				ret := offsetCubic(cubicPiece(left[0], left[1], left[2], left[3]), d, tolerance, depth+1)
+				return append(ret, offsetCubic(cubicPiece(right[0], right[1], right[2], right[3]), d, tolerance, depth+1)...)
+			}
+		}
+	}
+	return []piece{fit}
+}
+
+// flatten returns parameters along the piece whose points are within tolerance of the piece
+// when joined with straight lines. The first and last values are always 0 and 1.
+func (pc piece) flatten(tolerance float64) []float64 {
+	if !pc.cubic {
+		return []float64{0, 1}
+	}
+	return path.FlattenCubicParams(pc.p[:], tolerance)
+}
+
+// sourcePieces converts the segments of a path into pieces, skipping any of zero length. Arcs
+// are flattened into lines within the tolerance.
+func sourcePieces(p *path.Path, tolerance float64) []piece {
+	n := len(p.Segments)
+	count := n - 1
+	if p.Closed {
+		count = n
+	}
+	var ret []piece
+	for i := 0; i < count; i++ {
+		seg := p.Segments[i]
+		to := p.Segments[(i+1)%n].Point
+		if seg.Curve != nil {
+			if c1, c2, ok := seg.Curve.CubicControls(seg.Point, to); ok {
+				if !(seg.Point.Equals(to) && c1.Equals(to) && c2.Equals(to)) {
+					ret = append(ret, cubicPiece(seg.Point, c1, c2, to))
+				}
+				continue
+			}
+			if seg.Curve.Arc != nil {
+				ts := seg.Curve.FlattenParams(seg.Point, to, tolerance/4)
+				prev := seg.Point
+				for _, t := range ts[1:] {
+					next := to
+					if t < 1 {
+						next = point.NewPoint(seg.Curve.Interpolate(seg.Point, to, t))
+					}
+					if !prev.Equals(next) {
+						ret = append(ret, linePiece(prev, next))
+					}
+					prev = next
+				}
+				continue
+			}
+		}
+		if !seg.Point.Equals(to) {
+			ret = append(ret, linePiece(seg.Point, to))
+		}
+	}
+	return ret
+}
+
+func reversePieces(pieces []piece) []piece {
+	ret := make([]piece, len(pieces))
+	for i, pc := range pieces {
+		ret[len(pieces)-1-i] = pc.reverse()
+	}
+	return ret
+}
+
+// flattenSource returns the vertices of a polyline that approximates the pieces.
+// The closing vertex of a closed path is not repeated.
+func flattenSource(pieces []piece, closed bool, tolerance float64) []point.Point {
+	ret := []point.Point{pieces[0].start()}
+	for _, pc := range pieces {
+		ts := pc.flatten(tolerance / 4)
+		for _, t := range ts[1:] {
+			ret = append(ret, pc.at(t))
+		}
+	}
+	if closed && len(ret) > 1 {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
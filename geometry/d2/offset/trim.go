@@ -0,0 +1,353 @@
+package offset
+
+import (
+	"math"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// A flatPoint is a vertex of the flattened raw offset.
+type flatPoint struct {
+	point.Point
+	// piece is the index of the piece that the line starting at this point belongs to.
+	piece int
+	// t is the parameter of the point along its piece.
+	t float64
+	// orig is false for points that were created by cutting the offset at an intersection.
+	orig bool
+}
+
+type cut struct {
+	t float64
+	p point.Point
+}
+
+// trim removes the parts of the raw offset that fold back over themselves.
+// The raw offset is split wherever it intersects itself and every run between intersections
+// that comes closer than r to the source polyline is discarded. The remaining runs are joined
+// back together into paths.
+func trim(raw []piece, closed bool, source []point.Point, sourceClosed bool, r, tolerance float64, keepCurves bool) []*path.Path {
+	if len(raw) == 0 {
+		return nil
+	}
+	pts := flattenRaw(raw, tolerance/4)
+	cuts := selfIntersections(pts)
+	runs := splitRuns(pts, cuts, closed)
+
+	threshold := r - slack(raw, r, tolerance)
+	var kept [][]flatPoint
+	for _, run := range runs {
+		if isValidRun(run, raw, source, sourceClosed, threshold) {
+			kept = append(kept, run)
+		}
+	}
+
+	var ret []*path.Path
+	for _, chain := range chainRuns(kept, closed && len(cuts) == 0) {
+		if chainLength(chain) < tolerance {
+			continue
+		}
+		ring := len(chain) > 2 && chain[0].EqualsWithTolerance(chain[len(chain)-1].Point, 1e-6)
+		if p := toPath(chain, raw, ring, keepCurves); p != nil {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// slack returns how much closer than r to the source a run can come and still be kept. Fitted
+// curves are only within the tolerance of the true offset, but the offsets of lines are exact.
+// Allowing for error that is not there keeps the overshoots of inside corners that turn only
+// slightly, as the corners of finely divided curves do.
+func slack(raw []piece, r, tolerance float64) float64 {
+	for _, pc := range raw {
+		if pc.cubic && !pc.outer {
+			return math.Min(2*tolerance, r/2)
+		}
+	}
+	return 1e-9 * r
+}
+
+// flattenRaw returns the vertices of the flattened raw offset. The last vertex is the end of the
+// final piece, which is the start of the first piece for closed offsets.
+func flattenRaw(raw []piece, tolerance float64) []flatPoint {
+	var pts []flatPoint
+	for i, pc := range raw {
+		ts := pc.flatten(tolerance)
+		for j, t := range ts[:len(ts)-1] {
+			p := pc.at(t)
+			if j == 0 {
+				p = pc.start()
+				if len(pts) > 0 && pts[len(pts)-1].EqualsWithTolerance(p, 1e-9) {
+					pts = pts[:len(pts)-1]
+				}
+			}
+			pts = append(pts, flatPoint{p, i, t, true})
+		}
+		pts = append(pts, flatPoint{pc.end(), i, 1, true})
+	}
+	last := &pts[len(pts)-1]
+	last.piece = len(raw)
+	last.t = 0
+	return pts
+}
+
+// selfIntersections finds where the lines between the points cross each other.
+// The returned map is keyed by the index of the line's starting point.
+func selfIntersections(pts []flatPoint) map[int][]cut {
+	n := len(pts) - 1
+	type box struct{ minX, minY, maxX, maxY float64 }
+	boxes := make([]box, n)
+	for i := 0; i < n; i++ {
+		a, b := pts[i], pts[i+1]
+		boxes[i] = box{math.Min(a.X, b.X), math.Min(a.Y, b.Y), math.Max(a.X, b.X), math.Max(a.Y, b.Y)}
+	}
+	closed := n > 1 && pts[0].EqualsWithTolerance(pts[n].Point, 1e-9)
+
+	cuts := map[int][]cut{}
+	addCut := func(i int, t float64, p point.Point) {
+		if t >= 1 {
+			i, t = i+1, 0
+		}
+		if i == n && closed {
+			i = 0
+		}
+		for _, c := range cuts[i] {
+			if math.Abs(c.t-t) < 1e-9 {
+				return
+			}
+		}
+		cuts[i] = append(cuts[i], cut{t, p})
+	}
+	for i := 0; i < n; i++ {
+		bi := boxes[i]
+		for j := i + 2; j < n; j++ {
+			if closed && i == 0 && j == n-1 {
+				continue
+			}
+			bj := boxes[j]
+			if bi.maxX < bj.minX || bj.maxX < bi.minX || bi.maxY < bj.minY || bj.maxY < bi.minY {
+				continue
+			}
+			a, b, c, d := pts[i], pts[i+1], pts[j], pts[j+1]
+			if s, u, ok := line.GetIntersectionParams(a.X, a.Y, b.X, b.Y, c.X, c.Y, d.X, d.Y); ok {
+				p := a.AddPoint(b.SubtractPoint(a.Point).ScalarMult(s))
+				addCut(i, s, p)
+				addCut(j, u, p)
+			}
+		}
+	}
+	for i := range cuts {
+		sort.Slice(cuts[i], func(a, b int) bool {
+			return cuts[i][a].t < cuts[i][b].t
+		})
+	}
+	return cuts
+}
+
+// splitRuns cuts the flattened offset into runs that start and end at intersections.
+func splitRuns(pts []flatPoint, cuts map[int][]cut, closed bool) [][]flatPoint {
+	var runs [][]flatPoint
+	run := []flatPoint{pts[0]}
+	endRun := func(next flatPoint) {
+		if len(run) > 1 {
+			runs = append(runs, run)
+		}
+		run = []flatPoint{next}
+	}
+	for i := 0; i < len(pts)-1; i++ {
+		for _, c := range cuts[i] {
+			if c.t == 0 {
+				if i > 0 {
+					endRun(pts[i])
+				}
+				continue
+			}
+			a, b := pts[i], pts[i+1]
+			fp := flatPoint{c.p, a.piece, a.t + c.t*(b.t-a.t), false}
+			run = append(run, fp)
+			endRun(fp)
+		}
+		run = append(run, pts[i+1])
+	}
+	if len(run) > 1 {
+		runs = append(runs, run)
+	}
+	// The first and last runs of a closed offset meet at the starting point unless it was cut
+	if closed && len(runs) > 1 && !hasCutAtStart(cuts) {
+		last := runs[len(runs)-1]
+		runs[0] = append(last, runs[0][1:]...)
+		runs = runs[:len(runs)-1]
+	}
+	return runs
+}
+
+func hasCutAtStart(cuts map[int][]cut) bool {
+	for _, c := range cuts[0] {
+		if c.t == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidRun returns false if any part of the run other than its cut ends comes closer than
+// threshold to the source. Points on outer pieces are not tested.
+func isValidRun(run []flatPoint, raw []piece, source []point.Point, sourceClosed bool, threshold float64) bool {
+	isOuter := func(i int) bool {
+		pc := run[i].piece
+		return pc < len(raw) && raw[pc].outer
+	}
+	for i := 0; i < len(run)-1; i++ {
+		if isOuter(i) {
+			continue
+		}
+		mid := run[i].AddPoint(run[i+1].Point).ScalarMult(0.5)
+		if distanceTo(source, sourceClosed, mid) < threshold {
+			return false
+		}
+		if i > 0 && run[i].orig && distanceTo(source, sourceClosed, run[i].Point) < threshold {
+			return false
+		}
+	}
+	return true
+}
+
+func chainLength(chain []flatPoint) float64 {
+	ret := 0.
+	for i := 1; i < len(chain); i++ {
+		ret += chain[i].Distance(chain[i-1].Point)
+	}
+	return ret
+}
+
+// distanceTo returns the distance from p to the polyline
+func distanceTo(polyline []point.Point, closed bool, p point.Point) float64 {
+	if len(polyline) == 1 {
+		return polyline[0].Distance(p)
+	}
+	n := len(polyline) - 1
+	if closed {
+		n = len(polyline)
+	}
+	ret := math.Inf(1)
+	for i := 0; i < n; i++ {
+		a := polyline[i]
+		b := polyline[(i+1)%len(polyline)]
+		ret = math.Min(ret, line.DistanceToPoint(a.X, a.Y, b.X, b.Y, p.X, p.Y))
+	}
+	return ret
+}
+
+// chainRuns joins runs whose ends meet. If ring is true there is a single run that is closed.
+func chainRuns(runs [][]flatPoint, ring bool) [][]flatPoint {
+	if ring {
+		return runs
+	}
+	used := make([]bool, len(runs))
+	findNext := func(p point.Point) int {
+		for i, run := range runs {
+			if !used[i] && run[0].EqualsWithTolerance(p, 1e-6) {
+				return i
+			}
+		}
+		return -1
+	}
+	isContinuation := func(i int) bool {
+		for j, run := range runs {
+			if j != i && run[len(run)-1].EqualsWithTolerance(runs[i][0].Point, 1e-6) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var ret [][]flatPoint
+	build := func(start int) {
+		used[start] = true
+		chain := append([]flatPoint{}, runs[start]...)
+		for {
+			end := chain[len(chain)-1]
+			if len(chain) > 2 && end.EqualsWithTolerance(chain[0].Point, 1e-6) {
+				break
+			}
+			next := findNext(end.Point)
+			if next < 0 {
+				break
+			}
+			used[next] = true
+			chain = append(chain, runs[next][1:]...)
+		}
+		ret = append(ret, chain)
+	}
+	// Start open chains at runs that nothing leads into
+	for i := range runs {
+		if !used[i] && !isContinuation(i) {
+			build(i)
+		}
+	}
+	for i := range runs {
+		if !used[i] {
+			build(i)
+		}
+	}
+	return ret
+}
+
+// toPath converts a chain of flattened points back to a path. Pieces that survived trimming
+// whole are emitted as their original curves when keepCurves is true.
+func toPath(chain []flatPoint, raw []piece, closed, keepCurves bool) *path.Path {
+	var segments []path.Segment
+	for i := 0; i < len(chain); {
+		fp := chain[i]
+		if keepCurves && fp.orig && fp.t == 0 && fp.piece < len(raw) && raw[fp.piece].cubic {
+			j := i + 1
+			for j < len(chain) && chain[j].orig && chain[j].piece == fp.piece {
+				j++
+			}
+			if j < len(chain) && chain[j].orig {
+				pc := raw[fp.piece]
+				segments = append(segments, path.NewCubicBezierSegment(fp.Point, pc.p[1], pc.p[2]))
+				i = j
+				continue
+			}
+		}
+		segments = appendLine(segments, fp.Point)
+		i++
+	}
+	if closed {
+		segments = segments[:len(segments)-1]
+	}
+	if closed && len(segments) > 3 {
+		// The first point can be collinear with its neighbours across the closing line
+		last := segments[len(segments)-1]
+		if last.Curve == nil && segments[0].Curve == nil && isBetween(last.Point, segments[0].Point, segments[1].Point) {
+			segments = segments[1:]
+		}
+	}
+	if len(segments) < 2 {
+		return nil
+	}
+	return path.FromSegments(segments, closed)
+}
+
+// appendLine adds a line segment starting at p. The previous point is dropped if it lies on
+// the straight line between its neighbour and p.
+func appendLine(segments []path.Segment, p point.Point) []path.Segment {
+	n := len(segments)
+	if n >= 2 && segments[n-1].Curve == nil && segments[n-2].Curve == nil && isBetween(segments[n-2].Point, segments[n-1].Point, p) {
+		segments = segments[:n-1]
+	}
+	return append(segments, path.NewSegment(p.X, p.Y))
+}
+
+// isBetween returns true if b lies on the line from a to c
+func isBetween(a, b, c point.Point) bool {
+	ab := b.SubtractPoint(a)
+	bc := c.SubtractPoint(b)
+	cross := ab.X*bc.Y - ab.Y*bc.X
+	return math.Abs(cross) <= 1e-9*(ab.Magnitude()+bc.Magnitude()) && ab.Dot(bc) >= 0
+}
@@ -0,0 +1,271 @@
+package pack
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/circle"
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/srmullen/godraw-lib/geometry/d2/quadtree"
+)
+
+// DefaultAttempts is the number of placements in a row that can fail before packing stops
+const DefaultAttempts = 1000
+
+// Options control how shapes are packed.
+type Options struct {
+	// Gap is the minimum distance between shapes and between shapes and the container
+	Gap float64
+	// MinSize is the smallest size a shape is placed at. It is the radius of circles and the
+	// scale of polygons.
+	MinSize float64
+	// MaxSize is the largest size a shape grows to. Shapes start at MinSize and grow until
+	// they touch a neighbour or the container, or reach MaxSize. Defaults to no limit.
+	MaxSize float64
+	// Count is the largest number of shapes to place. Defaults to no limit.
+	Count int
+	// Attempts is the number of placements in a row that can fail before packing stops.
+	// Defaults to DefaultAttempts.
+	Attempts int
+	// KeepRotation places polygons without rotating them
+	KeepRotation bool
+	// Rand is the source of randomness. Defaults to a source with seed 0 so that the same
+	// options always give the same packing.
+	Rand *rand.Rand
+}
+
+func (o Options) maxSize() float64 {
+	if o.MaxSize <= 0 {
+		return math.Inf(1)
+	}
+	return math.Max(o.MaxSize, o.MinSize)
+}
+
+func (o Options) attempts() int {
+	if o.Attempts <= 0 {
+		return DefaultAttempts
+	}
+	return o.Attempts
+}
+
+func (o Options) rand() *rand.Rand {
+	if o.Rand == nil {
+		return rand.New(rand.NewSource(0))
+	}
+	return o.Rand
+}
+
+func (o Options) full(n int) bool {
+	return o.Count > 0 && n >= o.Count
+}
+
+// A container is the area shapes are packed into
+type container struct {
+	shape *polygon.PolygonWithHoles
+	rings [][]point.Point
+	b     bounds.Bounds
+}
+
+func newContainer(poly *polygon.Polygon, holes []*polygon.Polygon) container {
+	c := container{shape: polygon.NewPolygonWithHoles(poly, holes...), b: *poly.GetBounds()}
+	c.rings = append(c.rings, poly.Points())
+	for _, hole := range holes {
+		c.rings = append(c.rings, hole.Points())
+	}
+	return c
+}
+
+// sample returns a random point in the bounds of the container and whether it is inside it
+func (c container) sample(rng *rand.Rand) (point.Point, bool) {
+	p := point.NewPoint(c.b.Left+rng.Float64()*c.b.Width(), c.b.Top+rng.Float64()*c.b.Height())
+	return p, c.shape.ContainsPoint(p.X, p.Y)
+}
+
+// distance returns the distance from p to the boundary of the container
+func (c container) distance(p point.Point) float64 {
+	ret := math.Inf(1)
+	for _, ring := range c.rings {
+		for i := range ring {
+			a, b := ring[i], ring[(i+1)%len(ring)]
+			ret = math.Min(ret, line.DistanceToPoint(a.X, a.Y, b.X, b.Y, p.X, p.Y))
+		}
+	}
+	return ret
+}
+
+// Circles packs circles into the polygon, keeping them out of the holes. Each circle is placed
+// at a random point and grown until it touches its neighbours or the container.
+func Circles(opts Options, poly *polygon.Polygon, holes ...*polygon.Polygon) []circle.Circle {
+	c := newContainer(poly, holes)
+	rng := opts.rand()
+	maxSize := opts.maxSize()
+	tree := quadtree.New[int](c.b)
+	var ret []circle.Circle
+	for failed := 0; failed < opts.attempts() && !opts.full(len(ret)); failed++ {
+		center, ok := c.sample(rng)
+		if !ok {
+			continue
+		}
+		r := math.Min(maxSize, c.distance(center)-opts.Gap)
+		nearest := tree.NearestFunc(center, 1, func(b bounds.Bounds, i int) float64 {
+			return math.Max(center.Distance(ret[i].Center)-ret[i].Radius, 0)
+		})
+		for _, i := range nearest {
+			r = math.Min(r, center.Distance(ret[i].Center)-ret[i].Radius-opts.Gap)
+		}
+		if r < opts.MinSize || r <= 0 {
+			continue
+		}
+		circ := circle.Circle{Center: center, Radius: r}
+		tree.Insert(circ.Bounds(), len(ret))
+		ret = append(ret, circ)
+		failed = -1
+	}
+	return ret
+}
+
+// Polygons packs copies of the shapes into the polygon, keeping them out of the holes. Each
+// copy is picked at random from the shapes, rotated by a random angle around its centroid,
+// placed at a random point and scaled up until it touches its neighbours or the container.
+// A scale of 1 is the size of the shape as given.
+func Polygons(opts Options, shapes []*polygon.Polygon, poly *polygon.Polygon, holes ...*polygon.Polygon) []*polygon.Polygon {
+	if len(shapes) == 0 {
+		return nil
+	}
+	c := newContainer(poly, holes)
+	rng := opts.rand()
+
+	// Shapes are centered on their centroids so they rotate and scale in place
+	centered := make([][]point.Point, len(shapes))
+	for i, shape := range shapes {
+		centroid := shape.Centroid()
+		for _, p := range shape.Points() {
+			centered[i] = append(centered[i], p.SubtractPoint(centroid))
+		}
+	}
+	// No shape can grow past the point where it is larger than the container
+	diagonal := math.Hypot(c.b.Width(), c.b.Height())
+
+	tree := quadtree.New[int](c.b)
+	var placed [][]point.Point
+	var ret []*polygon.Polygon
+	for failed := 0; failed < opts.attempts() && !opts.full(len(ret)); failed++ {
+		center, ok := c.sample(rng)
+		if !ok {
+			continue
+		}
+		shape := centered[rng.Intn(len(centered))]
+		angle := 0.
+		if !opts.KeepRotation {
+			angle = rng.Float64() * 2 * math.Pi
+		}
+		radius := 0.
+		for _, p := range shape {
+			radius = math.Max(radius, p.Magnitude())
+		}
+		if radius == 0 {
+			continue
+		}
+		transform := func(scale float64) []point.Point {
+			ret := make([]point.Point, len(shape))
+			for i, p := range shape {
+				ret[i] = p.Rotate(angle).ScalarMult(scale).AddPoint(center)
+			}
+			return ret
+		}
+		fits := func(scale float64) bool {
+			return fits(opts.Gap, c, tree, placed, transform(scale))
+		}
+
+		// Without a minimum size any shape too small to see counts as fitting
+		lo := opts.MinSize
+		if lo <= 0 {
+			lo = 1e-6 * diagonal / radius
+		}
+		if !fits(lo) {
+			continue
+		}
+		hi := math.Max(lo, math.Min(opts.maxSize(), diagonal/radius))
+		if fits(hi) {
+			lo = hi
+		}
+		for i := 0; i < 20 && hi-lo > 1e-6*hi; i++ {
+			mid := (lo + hi) / 2
+			if fits(mid) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+
+		pts := transform(lo)
+		tree.Insert(ringBounds(pts), len(placed))
+		placed = append(placed, pts)
+		ret = append(ret, polygon.NewPolygon(polygon.Points(pts)))
+		failed = -1
+	}
+	return ret
+}
+
+// fits returns true if the ring is inside the container and at least gap away from its
+// boundary and from the placed rings
+func fits(gap float64, c container, tree *quadtree.Quadtree[int], placed [][]point.Point, ring []point.Point) bool {
+	if !c.shape.ContainsPoint(ring[0].X, ring[0].Y) {
+		return false
+	}
+	for _, other := range c.rings {
+		if ringDistance(ring, other) < gap {
+			return false
+		}
+	}
+	shape := polygon.NewPolygon(polygon.Points(ring))
+	// A hole that is entirely inside the ring does not come near its boundary
+	for _, hole := range c.rings[1:] {
+		if shape.ContainsPoint(hole[0].X, hole[0].Y) {
+			return false
+		}
+	}
+	b := ringBounds(ring)
+	b = bounds.NewBounds(b.Top-gap, b.Right+gap, b.Bottom+gap, b.Left-gap)
+	_, hit := tree.FirstHit(b, func(i int) bool {
+		other := placed[i]
+		return shape.ContainsPoint(other[0].X, other[0].Y) ||
+			polygon.NewPolygon(polygon.Points(other)).ContainsPoint(ring[0].X, ring[0].Y) ||
+			ringDistance(ring, other) < gap
+	})
+	return !hit
+}
+
+// ringDistance returns the smallest distance between the edges of the rings. It is -1 if they
+// cross, so that crossing rings are too close for any gap, including zero.
+func ringDistance(a, b []point.Point) float64 {
+	ret := math.Inf(1)
+	for i := range a {
+		p1, p2 := a[i], a[(i+1)%len(a)]
+		for j := range b {
+			q1, q2 := b[j], b[(j+1)%len(b)]
+			if _, _, ok := line.GetIntersectionParams(p1.X, p1.Y, p2.X, p2.Y, q1.X, q1.Y, q2.X, q2.Y); ok {
+				return -1
+			}
+			ret = math.Min(ret, math.Min(
+				math.Min(line.DistanceToPoint(p1.X, p1.Y, p2.X, p2.Y, q1.X, q1.Y), line.DistanceToPoint(p1.X, p1.Y, p2.X, p2.Y, q2.X, q2.Y)),
+				math.Min(line.DistanceToPoint(q1.X, q1.Y, q2.X, q2.Y, p1.X, p1.Y), line.DistanceToPoint(q1.X, q1.Y, q2.X, q2.Y, p2.X, p2.Y)),
+			))
+		}
+	}
+	return ret
+}
+
+func ringBounds(ring []point.Point) bounds.Bounds {
+	b := bounds.NewBounds(math.Inf(1), math.Inf(-1), math.Inf(-1), math.Inf(1))
+	for _, p := range ring {
+		b.Left = math.Min(b.Left, p.X)
+		b.Right = math.Max(b.Right, p.X)
+		b.Top = math.Min(b.Top, p.Y)
+		b.Bottom = math.Max(b.Bottom, p.Y)
+	}
+	return b
+}
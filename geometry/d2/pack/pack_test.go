@@ -0,0 +1,106 @@
+package pack
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircles(t *testing.T) {
+	container := polygon.NewRectangle(0, 0, 100, 100).Polygon
+	hole := polygon.NewRectangle(40, 40, 20, 20).Polygon
+	opts := Options{Gap: 1, MinSize: 2, MaxSize: 15}
+	circles := Circles(opts, container, hole)
+	assert.Greater(t, len(circles), 20)
+
+	for i, c := range circles {
+		assert.GreaterOrEqual(t, c.Radius, 2.)
+		assert.LessOrEqual(t, c.Radius, 15.)
+		assert.GreaterOrEqual(t, c.Center.X-c.Radius, 1-1e-9)
+		assert.LessOrEqual(t, c.Center.X+c.Radius, 99+1e-9)
+		assert.GreaterOrEqual(t, c.Center.Y-c.Radius, 1-1e-9)
+		assert.LessOrEqual(t, c.Center.Y+c.Radius, 99+1e-9)
+		assert.False(t, hole.ContainsPoint(c.Center.X, c.Center.Y))
+		assert.GreaterOrEqual(t, hole.Distance(c.Center.X, c.Center.Y), c.Radius+1-1e-9)
+		for _, other := range circles[:i] {
+			assert.GreaterOrEqual(t, c.Center.Distance(other.Center), c.Radius+other.Radius+1-1e-9)
+		}
+	}
+
+	t.Run("grows until collision", func(t *testing.T) {
+		// Without a maximum the first circle fills as much of the container as it can
+		circles := Circles(Options{MinSize: 1, Count: 1}, container)
+		assert.Equal(t, 1, len(circles))
+		c := circles[0]
+		d := math.Min(math.Min(c.Center.X, 100-c.Center.X), math.Min(c.Center.Y, 100-c.Center.Y))
+		assert.InDelta(t, d, c.Radius, 1e-9)
+	})
+
+	t.Run("seeded", func(t *testing.T) {
+		a := Circles(Options{MinSize: 2, Rand: rand.New(rand.NewSource(3))}, container)
+		b := Circles(Options{MinSize: 2, Rand: rand.New(rand.NewSource(3))}, container)
+		c := Circles(Options{MinSize: 2, Rand: rand.New(rand.NewSource(4))}, container)
+		assert.Equal(t, a, b)
+		assert.NotEqual(t, a, c)
+	})
+}
+
+func TestPolygons(t *testing.T) {
+	container := polygon.NewStar(100, 100, 100, 60, 5)
+	shapes := []*polygon.Polygon{
+		polygon.NewRectangle(-1, -1, 2, 2).Polygon,
+		polygon.NewStar(0, 0, 1, 0.5, 5),
+	}
+	opts := Options{Gap: 2, MinSize: 3, MaxSize: 20, Count: 40}
+	packed := Polygons(opts, shapes, container)
+	assert.Equal(t, 40, len(packed))
+
+	for i, p := range packed {
+		for _, pt := range p.Points() {
+			assert.True(t, container.ContainsPoint(pt.X, pt.Y))
+		}
+		assert.GreaterOrEqual(t, ringDistance(p.Points(), container.Points()), 2.)
+		for _, other := range packed[:i] {
+			assert.GreaterOrEqual(t, ringDistance(p.Points(), other.Points()), 2.)
+			assert.False(t, other.ContainsPoint(p.Points()[0].X, p.Points()[0].Y))
+		}
+	}
+	// Each is a copy of one of the shapes scaled by at least MinSize
+	for _, p := range packed {
+		n := len(p.Points())
+		assert.True(t, n == 4 || n == 10)
+		if n == 4 {
+			assert.GreaterOrEqual(t, p.Area(), 4*9-1e-6)
+		} else {
+			assert.GreaterOrEqual(t, p.Area(), shapes[1].Area()*9-1e-6)
+		}
+	}
+
+	t.Run("default options", func(t *testing.T) {
+		tri := polygon.NewNgon(3, 0, 0, 1)
+		square := polygon.NewRectangle(0, 0, 100, 100).Polygon
+		packed := Polygons(Options{Count: 5}, []*polygon.Polygon{tri}, square)
+		assert.Equal(t, 5, len(packed))
+		for i, p := range packed {
+			assert.Greater(t, p.Area(), 0.)
+			for _, pt := range p.Points() {
+				assert.True(t, square.ContainsPoint(pt.X, pt.Y))
+			}
+			// Without a gap shapes may touch but not cross
+			for _, other := range packed[:i] {
+				assert.GreaterOrEqual(t, ringDistance(p.Points(), other.Points()), 0.)
+			}
+		}
+	})
+
+	t.Run("keep rotation", func(t *testing.T) {
+		squares := Polygons(Options{MinSize: 2, KeepRotation: true, Count: 10}, shapes[:1], polygon.NewRectangle(0, 0, 50, 50).Polygon)
+		for _, sq := range squares {
+			pts := sq.Points()
+			assert.InDelta(t, pts[0].Y, pts[1].Y, 1e-9)
+		}
+	})
+}
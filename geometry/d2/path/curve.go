@@ -111,6 +111,21 @@ func (c *Curve) Length(p1, p2 point.Point) float64 {
 	return 0
 }
 
+// CubicControls returns the control points of the cubic bezier that is equivalent
+// to the curve running from p1 to p2. Quadratic curves are degree elevated.
+// ok is false for curves that have no exact cubic representation.
+func (c *Curve) CubicControls(p1, p2 point.Point) (c1, c2 point.Point, ok bool) {
+	if c.CubicBezier != nil {
+		return c.CubicBezier.C1, c.CubicBezier.C2, true
+	} else if c.QuadraticBezier != nil {
+		q := c.QuadraticBezier.C
+		c1 = p1.AddPoint(q.SubtractPoint(p1).ScalarMult(2. / 3))
+		c2 = p2.AddPoint(q.SubtractPoint(p2).ScalarMult(2. / 3))
+		return c1, c2, true
+	}
+	return point.Point{}, point.Point{}, false
+}
+
 type CubicBezier struct {
 	C1 point.Point
 	C2 point.Point
@@ -197,9 +212,88 @@ func (a *Arc) PathData() string {
 	return ret
 }
 
-// TODO: Needs implementation. Need to figure out how arc is calculated.
+// Interpolate returns the point at t along the arc from p1 to p2. Arcs with a zero radius are
+// straight lines, as in SVG.
 func (a *Arc) Interpolate(p1, p2 point.Point, t float64) (float64, float64) {
-	return 0, 0
+	e, ok := a.ellipse(p1, p2)
+	if !ok {
+		return interpolate(p1, p2, t).Coords()
+	}
+	return e.at(e.start + t*e.delta).Coords()
+}
+
+// Section returns the part of the arc from p1 to p2 between t0 and t1, as an arc that runs from
+// the point at t0 to the point at t1. If t1 is less than t0 the section runs backwards.
+func (a *Arc) Section(p1, p2 point.Point, t0, t1 float64) *Curve {
+	e, ok := a.ellipse(p1, p2)
+	if !ok {
+		return NewArc(a.Rx, a.Ry, a.Xrot, a.Large, a.Sweep)
+	}
+	// The radii of the ellipse are used as they may have been scaled up to reach p2
+	delta := e.delta * (t1 - t0)
+	return NewArc(e.rx, e.ry, a.Xrot, math.Abs(delta) > math.Pi, delta > 0)
+}
+
+// An ellipse is the center parameterization of an arc
+type ellipse struct {
+	center point.Point
+	rx, ry float64
+	// phi is the rotation of the x axis of the ellipse in radians
+	phi float64
+	// The arc runs from the start angle through delta radians
+	start, delta float64
+}
+
+func (e ellipse) at(theta float64) point.Point {
+	cos, sin := math.Cos(e.phi), math.Sin(e.phi)
+	x := e.rx * math.Cos(theta)
+	y := e.ry * math.Sin(theta)
+	return point.NewPoint(e.center.X+cos*x-sin*y, e.center.Y+sin*x+cos*y)
+}
+
+// ellipse converts the arc from p1 to p2 to its center parameterization. Radii that are too
+// small to reach p2 are scaled up. ok is false if the arc is a straight line or has no length.
+// https://www.w3.org/TR/SVG11/implnote.html#ArcConversionEndpointToCenter
+func (a *Arc) ellipse(p1, p2 point.Point) (ellipse, bool) {
+	rx, ry := math.Abs(a.Rx), math.Abs(a.Ry)
+	if rx == 0 || ry == 0 || p1.Equals(p2) {
+		return ellipse{}, false
+	}
+	phi := a.Xrot * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+	dx, dy := (p1.X-p2.X)/2, (p1.Y-p2.Y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(0, num/den))
+	if a.Large == a.Sweep {
+		coef = -coef
+	}
+	cx := coef * rx * y1 / ry
+	cy := -coef * ry * x1 / rx
+
+	start := math.Atan2((y1-cy)/ry, (x1-cx)/rx)
+	end := math.Atan2((-y1-cy)/ry, (-x1-cx)/rx)
+	delta := end - start
+	if a.Sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !a.Sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+	return ellipse{
+		center: point.NewPoint(cos*cx-sin*cy+(p1.X+p2.X)/2, sin*cx+cos*cy+(p1.Y+p2.Y)/2),
+		rx:     rx,
+		ry:     ry,
+		phi:    phi,
+		start:  start,
+		delta:  delta,
+	}, true
 }
 
 func (c1 *Curve) GetIntersections(c2 *Curve) {
@@ -0,0 +1,216 @@
+package path
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bezier"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// Fit returns a path of cubic beziers that passes within tolerance of every point of the path,
+// using Philip Schneider's algorithm from Graphics Gems. The path is treated as a polyline
+// through its points. Closed paths stay closed and are smooth where they join.
+func (p *Path) Fit(tolerance float64) *Path {
+	pts := dedupe(p.Points(), p.Closed)
+	if len(pts) < 2 || (p.Closed && len(pts) < 3) {
+		return FromSegments(append([]Segment{}, p.Segments...), p.Closed)
+	}
+	n := len(pts)
+	var start, end point.Point
+	if p.Closed {
+		// Both ends of a closed path share the tangent through the first point
+		start = pts[1].SubtractPoint(pts[n-1]).Normalize()
+		end = start.ScalarMult(-1)
+		pts = append(pts, pts[0])
+	} else {
+		start = pts[1].SubtractPoint(pts[0]).Normalize()
+		end = pts[n-2].SubtractPoint(pts[n-1]).Normalize()
+	}
+
+	var segments []Segment
+	fitCubic(pts, start, end, tolerance, func(b [4]point.Point) {
+		segments = append(segments, NewCubicBezierSegment(b[0], b[1], b[2]))
+	})
+	if !p.Closed {
+		segments = append(segments, Segment{Point: pts[len(pts)-1]})
+	}
+	return FromSegments(segments, p.Closed)
+}
+
+// fitCubic fits beziers to the points with the given tangents at each end, splitting them at the
+// point of greatest error until the fit is within tolerance
+func fitCubic(pts []point.Point, start, end point.Point, tolerance float64, emit func(b [4]point.Point)) {
+	first, last := pts[0], pts[len(pts)-1]
+	if len(pts) == 2 {
+		d := first.Distance(last) / 3
+		emit([4]point.Point{first, first.AddPoint(start.ScalarMult(d)), last.AddPoint(end.ScalarMult(d)), last})
+		return
+	}
+
+	u := chordLengths(pts)
+	b := generateBezier(pts, u, start, end)
+	err, split := maxError(pts, b, u)
+	if err <= tolerance {
+		emit(b)
+		return
+	}
+	// If the fit is close, improving the parameters may be enough
+	if err <= 4*tolerance {
+		for i := 0; i < 4; i++ {
+			u = reparameterize(pts, b, u)
+			b = generateBezier(pts, u, start, end)
+			if err, split = maxError(pts, b, u); err <= tolerance {
+				emit(b)
+				return
+			}
+		}
+	}
+
+	center := pts[split-1].SubtractPoint(pts[split+1])
+	if center.Magnitude() == 0 {
+		// The neighbours of the split coincide so use the direction perpendicular to them
+		center = pts[split-1].SubtractPoint(pts[split]).Normal()
+	}
+	center = center.Normalize()
+	fitCubic(pts[:split+1], start, center, tolerance, emit)
+	fitCubic(pts[split:], center.ScalarMult(-1), end, tolerance, emit)
+}
+
+// chordLengths returns a parameter for each point from 0 to 1 by the distance along the polyline
+func chordLengths(pts []point.Point) []float64 {
+	u := make([]float64, len(pts))
+	for i := 1; i < len(pts); i++ {
+		u[i] = u[i-1] + pts[i].Distance(pts[i-1])
+	}
+	for i := range u {
+		u[i] /= u[len(u)-1]
+	}
+	return u
+}
+
+// generateBezier finds the lengths of the control arms along the tangents that best fit the
+// points at their parameters, by least squares
+func generateBezier(pts []point.Point, u []float64, start, end point.Point) [4]point.Point {
+	first, last := pts[0], pts[len(pts)-1]
+	var c [2][2]float64
+	var x [2]float64
+	for i, p := range pts {
+		t := u[i]
+		mt := 1 - t
+		b0, b1, b2, b3 := mt*mt*mt, 3*t*mt*mt, 3*t*t*mt, t*t*t
+		a0 := start.ScalarMult(b1)
+		a1 := end.ScalarMult(b2)
+		c[0][0] += a0.Dot(a0)
+		c[0][1] += a0.Dot(a1)
+		c[1][1] += a1.Dot(a1)
+		tmp := p.SubtractPoint(first.ScalarMult(b0 + b1)).SubtractPoint(last.ScalarMult(b2 + b3))
+		x[0] += a0.Dot(tmp)
+		x[1] += a1.Dot(tmp)
+	}
+	c[1][0] = c[0][1]
+
+	det := c[0][0]*c[1][1] - c[1][0]*c[0][1]
+	alpha1, alpha2 := 0., 0.
+	if det != 0 {
+		alpha1 = (x[0]*c[1][1] - x[1]*c[0][1]) / det
+		alpha2 = (c[0][0]*x[1] - c[1][0]*x[0]) / det
+	}
+	// Fall back to a third of the chord if the arms are too short or point backwards
+	length := first.Distance(last)
+	if eps := 1e-6 * length; alpha1 < eps || alpha2 < eps {
+		alpha1 = length / 3
+		alpha2 = length / 3
+	}
+	return [4]point.Point{first, first.AddPoint(start.ScalarMult(alpha1)), last.AddPoint(end.ScalarMult(alpha2)), last}
+}
+
+// maxError returns the greatest distance from a point to the bezier at its parameter and the
+// index of that point, which is never one of the ends
+func maxError(pts []point.Point, b [4]point.Point, u []float64) (float64, int) {
+	ret, split := 0., len(pts)/2
+	for i := 1; i < len(pts)-1; i++ {
+		q := point.NewPoint(bezier.Polynomial(b[0], b[1], b[2], b[3], u[i]))
+		if d := q.Distance(pts[i]); d > ret {
+			ret, split = d, i
+		}
+	}
+	return ret, split
+}
+
+// reparameterize moves each parameter closer to the point on the bezier nearest its point with a
+// step of Newton's method
+func reparameterize(pts []point.Point, b [4]point.Point, u []float64) []float64 {
+	ret := make([]float64, len(u))
+	for i, t := range u {
+		q := point.NewPoint(bezier.Polynomial(b[0], b[1], b[2], b[3], t))
+		d1 := point.NewPoint(bezier.Derivative(b[0], b[1], b[2], b[3], t))
+		d2 := secondDerivative(b, t)
+		diff := q.SubtractPoint(pts[i])
+		denom := d1.Dot(d1) + diff.Dot(d2)
+		if denom == 0 {
+			ret[i] = t
+			continue
+		}
+		ret[i] = math.Max(0, math.Min(1, t-diff.Dot(d1)/denom))
+	}
+	return ret
+}
+
+func secondDerivative(b [4]point.Point, t float64) point.Point {
+	a := b[2].SubtractPoint(b[1].ScalarMult(2)).AddPoint(b[0])
+	c := b[3].SubtractPoint(b[2].ScalarMult(2)).AddPoint(b[1])
+	return a.ScalarMult(6 * (1 - t)).AddPoint(c.ScalarMult(6 * t))
+}
+
+// dedupe removes consecutive points that are equal, including the last point of a closed path
+// if it equals the first
+func dedupe(pts []point.Point, closed bool) []point.Point {
+	ret := make([]point.Point, 0, len(pts))
+	for _, p := range pts {
+		if len(ret) == 0 || !p.Equals(ret[len(ret)-1]) {
+			ret = append(ret, p)
+		}
+	}
+	if closed && len(ret) > 1 && ret[0].Equals(ret[len(ret)-1]) {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
+
+// Chaikin smooths the path by cutting each corner, replacing it with points a quarter of the way
+// along each of its edges. Each iteration doubles the number of points and the result approaches
+// a quadratic B-spline. Open paths keep their end points.
+func (p *Path) Chaikin(iterations int) *Path {
+	pts := p.Points()
+	for k := 0; k < iterations && len(pts) > 2; k++ {
+		n := len(pts)
+		next := make([]point.Point, 0, 2*n)
+		edges := n - 1
+		if p.Closed {
+			edges = n
+		} else {
+			next = append(next, pts[0])
+		}
+		for i := 0; i < edges; i++ {
+			a, b := pts[i], pts[(i+1)%n]
+			q := a.ScalarMult(0.75).AddPoint(b.ScalarMult(0.25))
+			r := a.ScalarMult(0.25).AddPoint(b.ScalarMult(0.75))
+			if !p.Closed && i == 0 {
+				next = append(next, r)
+			} else if !p.Closed && i == edges-1 {
+				next = append(next, q)
+			} else {
+				next = append(next, q, r)
+			}
+		}
+		if !p.Closed {
+			next = append(next, pts[n-1])
+		}
+		pts = next
+	}
+	coords := make([]float64, 0, 2*len(pts))
+	for _, pt := range pts {
+		coords = append(coords, pt.X, pt.Y)
+	}
+	return NewPath(coords, p.Closed)
+}
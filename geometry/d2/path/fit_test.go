@@ -0,0 +1,113 @@
+package path
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bezier"
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+// sample returns points along each cubic of the path
+func sample(p *Path) []point.Point {
+	var ret []point.Point
+	n := len(p.Segments)
+	for i, seg := range p.Segments {
+		if seg.Curve == nil {
+			continue
+		}
+		to := p.Segments[(i+1)%n].Point
+		for t := 0.; t <= 1; t += 0.001 {
+			ret = append(ret, point.NewPoint(bezier.Polynomial(seg.Point, seg.Curve.C1, seg.Curve.C2, to, t)))
+		}
+	}
+	return ret
+}
+
+// closest returns the distance from p to the nearest sample
+func closest(samples []point.Point, p point.Point) float64 {
+	ret := math.Inf(1)
+	for _, s := range samples {
+		ret = math.Min(ret, s.Distance(p))
+	}
+	return ret
+}
+
+func TestFit(t *testing.T) {
+	t.Run("noisy wave", func(t *testing.T) {
+		p := wave(200)
+		fit := p.Fit(0.5)
+		assert.Less(t, len(fit.Segments), 20)
+		assert.Equal(t, p.Segments[0].Point, fit.Segments[0].Point)
+		assert.Equal(t, p.Segments[199].Point, fit.Segments[len(fit.Segments)-1].Point)
+		samples := sample(fit)
+		for _, pt := range p.Points() {
+			assert.Less(t, closest(samples, pt), 0.6)
+		}
+		// The curves are joined smoothly
+		for i := 1; i < len(fit.Segments)-1; i++ {
+			in := fit.Segments[i].Point.SubtractPoint(fit.Segments[i-1].Curve.C2).Normalize()
+			out := fit.Segments[i].Curve.C1.SubtractPoint(fit.Segments[i].Point).Normalize()
+			assert.InDelta(t, 1, in.Dot(out), 1e-9)
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		coords := []float64{}
+		for i := 0; i < 60; i++ {
+			a := float64(i) / 60 * 2 * math.Pi
+			coords = append(coords, 50+20*math.Cos(a), 50+20*math.Sin(a))
+		}
+		p := NewClosedPath(coords)
+		fit := p.Fit(0.1)
+		assert.True(t, fit.Closed)
+		assert.LessOrEqual(t, len(fit.Segments), 8)
+		samples := sample(fit)
+		for _, pt := range p.Points() {
+			assert.Less(t, closest(samples, pt), 0.15)
+		}
+	})
+
+	t.Run("two points", func(t *testing.T) {
+		fit := NewOpenPath([]float64{0, 0, 3, 0}).Fit(1)
+		assert.Equal(t, 2, len(fit.Segments))
+		assert.Equal(t, point.NewPoint(1, 0), fit.Segments[0].Curve.C1)
+		assert.Equal(t, point.NewPoint(2, 0), fit.Segments[0].Curve.C2)
+	})
+}
+
+func TestChaikin(t *testing.T) {
+	square := NewClosedPath([]float64{0, 0, 4, 0, 4, 4, 0, 4})
+	smooth := square.Chaikin(1)
+	assert.Equal(t, []point.Point{{X: 1, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 1}, {X: 4, Y: 3}, {X: 3, Y: 4}, {X: 1, Y: 4}, {X: 0, Y: 3}, {X: 0, Y: 1}}, smooth.Points())
+	assert.True(t, smooth.Closed)
+	assert.Equal(t, 32, len(square.Chaikin(3).Segments))
+
+	open := NewOpenPath([]float64{0, 0, 4, 0, 4, 4})
+	assert.Equal(t, []point.Point{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 1}, {X: 4, Y: 4}}, open.Chaikin(1).Points())
+}
+
+func TestCatmullRom(t *testing.T) {
+	p := NewOpenPath([]float64{0, 0, 10, 5, 20, 0, 30, 5})
+	cr := p.CatmullRom()
+	// Passes through every point
+	assert.Equal(t, p.Points(), cr.Points())
+	assert.Equal(t, 4, len(cr.Segments))
+	assert.NotNil(t, cr.Segments[0].Curve)
+	assert.Nil(t, cr.Segments[3].Curve)
+	// The tangent at an inner point is parallel to the line between its neighbours
+	c2 := cr.Segments[0].Curve.C2
+	c1 := cr.Segments[1].Curve.C1
+	assert.InDelta(t, 0, line.DistanceToPoint(c2.X, c2.Y, c1.X, c1.Y, 10, 5), 1e-9)
+	assert.InDelta(t, 10+20./6, c1.X, 1e-9)
+	assert.InDelta(t, 5, c1.Y, 1e-9)
+
+	closed := NewClosedPath([]float64{0, 0, 10, 0, 10, 10, 0, 10}).CatmullRom()
+	assert.True(t, closed.Closed)
+	assert.Equal(t, 4, len(closed.Segments))
+	for _, seg := range closed.Segments {
+		assert.NotNil(t, seg.Curve)
+	}
+}
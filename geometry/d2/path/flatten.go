@@ -0,0 +1,103 @@
+package path
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// maxDepth limits how many times a curve is halved when it is flattened
+const maxDepth = 16
+
+// MinTolerance is the smallest tolerance curves are flattened to. Smaller tolerances, including
+// zero, are raised to it so the number of points stays reasonable.
+const MinTolerance = 1e-4
+
+// Flatten returns a path made only of lines that is never further than tolerance from the path.
+// Curves are subdivided until each piece is flat enough, so gentle curves need few lines. The
+// points of the path are all kept. Tolerances below MinTolerance are raised to it.
+func (p *Path) Flatten(tolerance float64) *Path {
+	n := len(p.Segments)
+	segments := make([]Segment, 0, n)
+	for i, seg := range p.Segments {
+		segments = append(segments, Segment{Point: seg.Point})
+		if seg.Curve == nil || (i == n-1 && !p.Closed) {
+			continue
+		}
+		to := p.Segments[(i+1)%n].Point
+		for _, pt := range seg.Curve.flatten(seg.Point, to, tolerance) {
+			segments = append(segments, Segment{Point: pt})
+		}
+	}
+	return FromSegments(segments, p.Closed)
+}
+
+// FlattenParams returns parameters along the curve from p1 to p2, from 0 to 1, whose points are
+// within tolerance of the curve when joined with lines
+func (c *Curve) FlattenParams(p1, p2 point.Point, tolerance float64) []float64 {
+	if c1, c2, ok := c.CubicControls(p1, p2); ok {
+		return FlattenCubicParams([]point.Point{p1, c1, c2, p2}, tolerance)
+	}
+	if c.Arc != nil {
+		return c.Arc.flattenParams(p1, p2, tolerance)
+	}
+	return []float64{0, 1}
+}
+
+// flatten returns the points between p1 and p2 that approximate the curve with lines within the
+// tolerance
+func (c *Curve) flatten(p1, p2 point.Point, tolerance float64) []point.Point {
+	ts := c.FlattenParams(p1, p2, tolerance)
+	ret := make([]point.Point, 0, len(ts)-2)
+	for _, t := range ts[1 : len(ts)-1] {
+		ret = append(ret, point.NewPoint(c.Interpolate(p1, p2, t)))
+	}
+	return ret
+}
+
+// FlattenCubicParams returns parameters along the cubic bezier b, from 0 to 1, whose points are
+// within tolerance of it when joined with lines. The cubic is halved until its control points
+// are within tolerance of the line between its ends, and as the curve lies inside the hull of its
+// control points it is within the tolerance as well.
+func FlattenCubicParams(b []point.Point, tolerance float64) []float64 {
+	tolerance = math.Max(tolerance, MinTolerance)
+	ts := []float64{0}
+	var recurse func(b []point.Point, t0, t1 float64, depth int)
+	recurse = func(b []point.Point, t0, t1 float64, depth int) {
+		d1 := line.DistanceToPoint(b[0].X, b[0].Y, b[3].X, b[3].Y, b[1].X, b[1].Y)
+		d2 := line.DistanceToPoint(b[0].X, b[0].Y, b[3].X, b[3].Y, b[2].X, b[2].Y)
+		if math.Max(d1, d2) <= tolerance || depth >= maxDepth {
+			ts = append(ts, t1)
+			return
+		}
+		left, right := Subdivide(b, 0.5)
+		mid := (t0 + t1) / 2
+		recurse(left, t0, mid, depth+1)
+		recurse(right, mid, t1, depth+1)
+	}
+	recurse(b, 0, 1, 0)
+	return ts
+}
+
+// flattenParams splits the arc into equal angles small enough that each chord is within
+// tolerance of the larger radius
+func (a *Arc) flattenParams(p1, p2 point.Point, tolerance float64) []float64 {
+	e, ok := a.ellipse(p1, p2)
+	if !ok {
+		return []float64{0, 1}
+	}
+	tolerance = math.Max(tolerance, MinTolerance)
+	r := math.Max(e.rx, e.ry)
+	// The chord of an angle theta is r * (1 - cos(theta / 2)) from the arc
+	step := 2 * math.Acos(math.Max(-1, 1-tolerance/r))
+	n := 1 << maxDepth
+	if step > 0 {
+		n = max(1, min(n, int(math.Ceil(math.Abs(e.delta)/step))))
+	}
+	ret := make([]float64, n+1)
+	for i := range ret {
+		ret[i] = float64(i) / float64(n)
+	}
+	return ret
+}
@@ -0,0 +1,154 @@
+package path
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenCubic(t *testing.T) {
+	seg := Segment{Point: point.NewPoint(0, 0), Curve: NewCubicBezier(point.NewPoint(0, 50), point.NewPoint(100, 50))}
+	p := FromSegments([]Segment{seg, {Point: point.NewPoint(100, 0)}}, false)
+	tolerance := 0.1
+	flat := p.Flatten(tolerance)
+	assert.False(t, flat.Closed)
+	for _, s := range flat.Segments {
+		assert.Nil(t, s.Curve)
+	}
+	pts := flat.Points()
+	assert.Equal(t, point.NewPoint(0, 0), pts[0])
+	assert.Equal(t, point.NewPoint(100, 0), pts[len(pts)-1])
+	assert.Greater(t, len(pts), 4)
+
+	// Every point on the curve is close to the lines
+	for i := 0; i <= 100; i++ {
+		x, y := seg.Interpolate(point.NewPoint(100, 0), float64(i)/100)
+		assert.LessOrEqual(t, distanceToPolyline(point.NewPoint(x, y), pts), tolerance+1e-9)
+	}
+
+	// A looser tolerance needs fewer points
+	assert.Less(t, len(p.Flatten(1).Points()), len(pts))
+}
+
+func TestFlattenQuadratic(t *testing.T) {
+	seg := Segment{Point: point.NewPoint(0, 0), Curve: NewQuadraticBezier(point.NewPoint(50, 100))}
+	p := FromSegments([]Segment{seg, {Point: point.NewPoint(100, 0)}}, false)
+	pts := p.Flatten(0.5).Points()
+	assert.Greater(t, len(pts), 2)
+	for i := 0; i <= 100; i++ {
+		x, y := seg.Interpolate(point.NewPoint(100, 0), float64(i)/100)
+		assert.LessOrEqual(t, distanceToPolyline(point.NewPoint(x, y), pts), 0.5+1e-9)
+	}
+}
+
+func TestFlattenArc(t *testing.T) {
+	// A semicircle of radius 50 centered on 50, 0
+	seg := Segment{Point: point.NewPoint(0, 0), Curve: NewArc(50, 50, 0, false, true)}
+	p := FromSegments([]Segment{seg, {Point: point.NewPoint(100, 0)}}, false)
+	tolerance := 0.1
+	pts := p.Flatten(tolerance).Points()
+	assert.Greater(t, len(pts), 10)
+	center := point.NewPoint(50, 0)
+	for _, pt := range pts {
+		assert.InDelta(t, 50, pt.Distance(center), 1e-9)
+	}
+	for i := 1; i < len(pts); i++ {
+		mid := pts[i].AddPoint(pts[i-1]).ScalarMult(0.5)
+		assert.LessOrEqual(t, 50-mid.Distance(center), tolerance+1e-9)
+	}
+}
+
+func TestArcInterpolate(t *testing.T) {
+	arc := NewArc(50, 50, 0, false, true).Arc
+	p1, p2 := point.NewPoint(0, 0), point.NewPoint(100, 0)
+	x, y := arc.Interpolate(p1, p2, 0)
+	assert.InDelta(t, 0, x, 1e-9)
+	assert.InDelta(t, 0, y, 1e-9)
+	x, y = arc.Interpolate(p1, p2, 1)
+	assert.InDelta(t, 100, x, 1e-9)
+	assert.InDelta(t, 0, y, 1e-9)
+	// Sweeping clockwise from the left goes up the screen
+	x, y = arc.Interpolate(p1, p2, 0.5)
+	assert.InDelta(t, 50, x, 1e-9)
+	assert.InDelta(t, -50, y, 1e-9)
+
+	// A zero radius is a line
+	x, y = NewArc(0, 50, 0, false, true).Arc.Interpolate(p1, p2, 0.5)
+	assert.InDelta(t, 50, x, 1e-9)
+	assert.InDelta(t, 0, y, 1e-9)
+}
+
+func TestArcSection(t *testing.T) {
+	arc := NewArc(30, 20, 30, true, false).Arc
+	p1, p2 := point.NewPoint(0, 0), point.NewPoint(40, 10)
+	for _, ts := range [][2]float64{{0.2, 0.7}, {0, 0.9}, {0.6, 0.1}} {
+		start := point.NewPoint(arc.Interpolate(p1, p2, ts[0]))
+		end := point.NewPoint(arc.Interpolate(p1, p2, ts[1]))
+		section := arc.Section(p1, p2, ts[0], ts[1]).Arc
+		for _, u := range []float64{0, 0.3, 0.5, 1} {
+			x, y := section.Interpolate(start, end, u)
+			ex, ey := arc.Interpolate(p1, p2, ts[0]+u*(ts[1]-ts[0]))
+			assert.InDelta(t, ex, x, 1e-6)
+			assert.InDelta(t, ey, y, 1e-6)
+		}
+	}
+
+	// Radii too small to reach the end are scaled up for the section too
+	small := NewArc(10, 10, 0, false, true).Arc
+	to := point.NewPoint(100, 0)
+	section := small.Section(point.NewPoint(0, 0), to, 0, 0.5).Arc
+	x, y := section.Interpolate(point.NewPoint(0, 0), point.NewPoint(50, -50), 0.5)
+	ex, ey := small.Interpolate(point.NewPoint(0, 0), to, 0.25)
+	assert.InDelta(t, ex, x, 1e-6)
+	assert.InDelta(t, ey, y, 1e-6)
+}
+
+func TestFlattenTolerance(t *testing.T) {
+	semicircle := FromSegments([]Segment{
+		{Point: point.NewPoint(0, 0), Curve: NewArc(50, 50, 0, false, true)},
+		{Point: point.NewPoint(100, 0)},
+	}, false)
+	// Tolerances below the minimum are raised to it
+	assert.Equal(t, semicircle.Flatten(MinTolerance).Points(), semicircle.Flatten(0).Points())
+	assert.Equal(t, semicircle.Flatten(MinTolerance).Points(), semicircle.Flatten(-1).Points())
+	assert.Less(t, len(semicircle.Flatten(0).Points()), 1000)
+
+	b := []point.Point{{X: 0, Y: 0}, {X: 0, Y: 50}, {X: 100, Y: 50}, {X: 100, Y: 0}}
+	ts := FlattenCubicParams(b, 0)
+	assert.Equal(t, 0., ts[0])
+	assert.Equal(t, 1., ts[len(ts)-1])
+	assert.Equal(t, FlattenCubicParams(b, MinTolerance), ts)
+}
+
+func TestFlattenClosed(t *testing.T) {
+	// The curve on the last segment of a closed path runs back to the start
+	p := FromSegments([]Segment{
+		{Point: point.NewPoint(0, 0)},
+		{Point: point.NewPoint(100, 0), Curve: NewQuadraticBezier(point.NewPoint(50, 100))},
+	}, true)
+	flat := p.Flatten(0.5)
+	assert.True(t, flat.Closed)
+	assert.Greater(t, len(flat.Segments), 3)
+
+	// On an open path it is ignored
+	p.Closed = false
+	assert.Len(t, p.Flatten(0.5).Segments, 2)
+}
+
+func TestFlattenLines(t *testing.T) {
+	p := NewClosedPath([]float64{0, 0, 10, 0, 10, 10})
+	assert.Equal(t, p.Points(), p.Flatten(0.1).Points())
+}
+
+func distanceToPolyline(p point.Point, pts []point.Point) float64 {
+	ret := math.Inf(1)
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		ab := b.SubtractPoint(a)
+		t := math.Max(0, math.Min(1, p.SubtractPoint(a).Dot(ab)/ab.Dot(ab)))
+		ret = math.Min(ret, p.Distance(a.AddPoint(ab.ScalarMult(t))))
+	}
+	return ret
+}
@@ -0,0 +1,122 @@
+package path
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// nearestSamples is the number of pieces a curve is split into to find the minima of its
+// distance before they are refined
+const nearestSamples = 32
+
+// NearestPoint returns the point on the path closest to q, with its T set to the path parameter
+// used by Interpolate, and the distance to it. Curves are searched along their length rather
+// than only at their points. An empty path returns an infinite distance.
+func (p *Path) NearestPoint(q point.Point) (point.InterpolationPoint, float64) {
+	best := point.InterpolationPoint{}
+	dist := math.Inf(1)
+	n := len(p.Segments)
+	if n == 1 {
+		best.Point = p.Segments[0].Point
+		return best, q.Distance(best.Point)
+	}
+	for i, seg := range p.Segments {
+		if !p.Closed && i == n-1 {
+			break
+		}
+		pt, t := seg.NearestPoint(p.Segments[(i+1)%n].Point, q)
+		if d := q.Distance(pt); d < dist {
+			best = point.InterpolationPoint{Point: pt, T: float64(i) + t}
+			dist = d
+		}
+	}
+	return best, dist
+}
+
+// NearestPoint returns the point on the segment ending at to that is closest to q and how far
+// along the segment it is
+func (s Segment) NearestPoint(to, q point.Point) (point.Point, float64) {
+	if s.Curve == nil {
+		return nearestOnLine(s.Point, to, q)
+	}
+	if s.Curve.Arc != nil {
+		if _, ok := s.Curve.Arc.ellipse(s.Point, to); !ok {
+			return nearestOnLine(s.Point, to, q)
+		}
+	}
+	at := func(t float64) point.Point {
+		return point.NewPoint(s.Interpolate(to, t))
+	}
+	// f is half the derivative of the squared distance, which is zero at the minima
+	f := func(t float64) (float64, float64) {
+		d1, d2, _ := s.derivatives(to, t)
+		diff := at(t).SubtractPoint(q)
+		return diff.Dot(d1), d1.Dot(d1) + diff.Dot(d2)
+	}
+
+	// Every sample closer than its neighbours is refined, including the ends, as the minimum can
+	// lie between an end and the next sample
+	dists := make([]float64, nearestSamples+1)
+	for i := range dists {
+		dists[i] = q.Distance(at(float64(i) / nearestSamples))
+	}
+	bestT, best := 0., math.Inf(1)
+	for i := range dists {
+		if (i > 0 && dists[i] > dists[i-1]) || (i < nearestSamples && dists[i] > dists[i+1]) {
+			continue
+		}
+		lo := float64(max(i-1, 0)) / nearestSamples
+		hi := float64(min(i+1, nearestSamples)) / nearestSamples
+		t := refine(f, float64(i)/nearestSamples, lo, hi)
+		if d := q.Distance(at(t)); d < best {
+			bestT, best = t, d
+		}
+	}
+	switch bestT {
+	case 0:
+		return s.Point, 0
+	case 1:
+		return to, 1
+	}
+	return at(bestT), bestT
+}
+
+// refine uses Newton's method to find where f is zero between lo and hi, starting from t. Steps
+// that leave the interval are replaced by bisection.
+func refine(f func(t float64) (float64, float64), t, lo, hi float64) float64 {
+	for i := 0; i < 50; i++ {
+		v, dv := f(t)
+		if v == 0 {
+			return t
+		}
+		// The distance shrinks towards the minimum, which is on the side of t that v points away
+		// from
+		if v > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+		next := t - v/dv
+		if dv <= 0 || next <= lo || next >= hi || math.IsNaN(next) {
+			next = (lo + hi) / 2
+		}
+		if math.Abs(next-t) < 1e-12 {
+			return next
+		}
+		t = next
+	}
+	return t
+}
+
+// nearestOnLine returns the point on the line from a to b closest to q and how far along the line
+// it is
+func nearestOnLine(a, b, q point.Point) (point.Point, float64) {
+	ab := b.SubtractPoint(a)
+	l := ab.Dot(ab)
+	if l == 0 {
+		return a, 0
+	}
+	t := math.Max(0, math.Min(1, q.SubtractPoint(a).Dot(ab)/l))
+	return a.AddPoint(ab.ScalarMult(t)), t
+}
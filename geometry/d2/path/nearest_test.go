@@ -0,0 +1,82 @@
+package path
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearestPointLines(t *testing.T) {
+	p := NewOpenPath([]float64{0, 0, 10, 0, 10, 10})
+	nearest, d := p.NearestPoint(point.NewPoint(4, 3))
+	assert.Equal(t, point.NewPoint(4, 0), nearest.Point)
+	assert.InDelta(t, 0.4, nearest.T, 1e-12)
+	assert.Equal(t, 3., d)
+
+	nearest, d = p.NearestPoint(point.NewPoint(13, 5))
+	assert.Equal(t, point.NewPoint(10, 5), nearest.Point)
+	assert.InDelta(t, 1.5, nearest.T, 1e-12)
+	assert.Equal(t, 3., d)
+
+	// The closing edge only counts for closed paths
+	q := point.NewPoint(3, 7)
+	_, open := p.NearestPoint(q)
+	p.Closed = true
+	nearest, closed := p.NearestPoint(q)
+	assert.Less(t, closed, open)
+	assert.Greater(t, nearest.T, 2.)
+
+	nearest, d = NewOpenPath([]float64{1, 1}).NearestPoint(point.NewPoint(4, 5))
+	assert.Equal(t, point.NewPoint(1, 1), nearest.Point)
+	assert.Equal(t, 5., d)
+	_, d = NewOpenPath(nil).NearestPoint(point.NewPoint(4, 5))
+	assert.True(t, math.IsInf(d, 1))
+}
+
+func TestNearestPointCurves(t *testing.T) {
+	p := FromSegments([]Segment{
+		NewCubicBezierSegment(point.NewPoint(0, 0), point.NewPoint(100, 100), point.NewPoint(0, 100)),
+		{Point: point.NewPoint(100, 0), Curve: NewQuadraticBezier(point.NewPoint(150, 80))},
+		{Point: point.NewPoint(120, 120), Curve: NewArc(40, 20, 30, true, false)},
+		{Point: point.NewPoint(200, 50)},
+	}, false)
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		q := point.NewPoint(rng.Float64()*250-25, rng.Float64()*200-50)
+		nearest, d := p.NearestPoint(q)
+		assert.InDelta(t, d, q.Distance(nearest.Point), 1e-9)
+		x, y := p.Interpolate(nearest.T)
+		assertPointInDelta(t, nearest.Point, point.NewPoint(x, y), 1e-9)
+
+		// No point along the path is closer
+		brute := math.Inf(1)
+		for k := 0; k <= 6000; k++ {
+			x, y := p.Interpolate(float64(k) / 2000)
+			brute = math.Min(brute, q.Distance(point.NewPoint(x, y)))
+		}
+		assert.LessOrEqual(t, d, brute+1e-9)
+		assert.InDelta(t, brute, d, 0.05)
+	}
+}
+
+func TestNearestPointArc(t *testing.T) {
+	// A semicircle of radius 50 centered on 50, 0
+	s := Segment{Point: point.NewPoint(0, 0), Curve: NewArc(50, 50, 0, false, true)}
+	to := point.NewPoint(100, 0)
+	pt, u := s.NearestPoint(to, point.NewPoint(50+30, -30))
+	assertPointInDelta(t, point.NewPoint(50+50/math.Sqrt2, -50/math.Sqrt2), pt, 1e-9)
+	assert.InDelta(t, 0.75, u, 1e-9)
+
+	// The center is the same distance from every point
+	_, u = s.NearestPoint(to, point.NewPoint(50, 0))
+	assert.GreaterOrEqual(t, u, 0.)
+	assert.LessOrEqual(t, u, 1.)
+
+	// Below the chord the ends are nearest
+	pt, u = s.NearestPoint(to, point.NewPoint(-10, 20))
+	assert.Equal(t, point.NewPoint(0, 0), pt)
+	assert.Equal(t, 0., u)
+}
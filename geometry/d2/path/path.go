@@ -7,7 +7,6 @@ import (
 	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
 	"github.com/srmullen/godraw-lib/geometry/d2/line"
 	"github.com/srmullen/godraw-lib/geometry/d2/point"
-	"github.com/srmullen/godraw-lib/util"
 )
 
 // https://www.w3.org/TR/SVG11/paths.html
@@ -115,56 +114,36 @@ func PathTranslate(path *Path, x, y float64) *Path {
 	return ret
 }
 
-// Bounds returns the bounding box of the path
+// Bounds returns the bounding box of the path. Curves are measured where they turn back along x or
+// y so the box fits them exactly.
 func (p *Path) GetBounds() *bounds.Bounds {
 	if p == nil || len(p.Segments) == 0 {
 		return nil
 	}
-	minX := math.Inf(1)
-	minY := math.Inf(1)
-	maxX := math.Inf(-1)
-	maxY := math.Inf(-1)
-	for i := 0; i < len(p.Segments); i++ {
-		segment := p.Segments[i]
-		if segment.Curve == nil {
-			if segment.X < minX {
-				minX = segment.X
-			}
-			if segment.Y < minY {
-				minY = segment.Y
-			}
-			if segment.X > maxX {
-				maxX = segment.X
-			}
-			if segment.Y > maxY {
-				maxY = segment.Y
-			}
-		} else {
-			next := p.Segments[util.Mod(i+1, len(p.Segments))]
-			steps := util.Linspace(0.0, 1.0, 10) // Number of interpolation steps
-			for _, t := range steps {
-				x, y := segment.Curve.Interpolate(segment.Point, next.Point, t)
-				if x < minX {
-					minX = x
-				}
-				if y < minY {
-					minY = y
-				}
-				if x > maxX {
-					maxX = x
-				}
-				if y > maxY {
-					maxY = y
-				}
-			}
-		}
+	ret := &bounds.Bounds{
+		Top:    math.Inf(1),
+		Right:  math.Inf(-1),
+		Bottom: math.Inf(-1),
+		Left:   math.Inf(1),
+	}
+	add := func(x, y float64) {
+		ret.Top = math.Min(ret.Top, y)
+		ret.Right = math.Max(ret.Right, x)
+		ret.Bottom = math.Max(ret.Bottom, y)
+		ret.Left = math.Min(ret.Left, x)
 	}
-	return &bounds.Bounds{
-		Top:    minY,
-		Right:  maxX,
-		Bottom: maxY,
-		Left:   minX,
+	n := len(p.Segments)
+	for i, segment := range p.Segments {
+		add(segment.X, segment.Y)
+		if segment.Curve == nil || (i == n-1 && !p.Closed) {
+			continue
+		}
+		next := p.Segments[(i+1)%n].Point
+		for _, t := range segment.extrema(next) {
+			add(segment.Interpolate(next, t))
+		}
 	}
+	return ret
 }
 
 func ScaleSegments(segments []Segment, scalex, scaley float64) []Segment {
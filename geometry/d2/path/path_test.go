@@ -11,6 +11,39 @@ func TestGetBounds(t *testing.T) {
 	p := NewClosedPath([]float64{})
 	bounds := p.GetBounds()
 	assert.Nil(t, bounds)
+
+	// A cubic reaches three quarters of the way to its control points
+	p = FromSegments([]Segment{
+		NewCubicBezierSegment(point.NewPoint(0, 0), point.NewPoint(0, -40), point.NewPoint(100, -40)),
+		NewSegment(100, 0),
+	}, false)
+	bounds = p.GetBounds()
+	assert.InDelta(t, -30, bounds.Top, 1e-9)
+	assert.Equal(t, 0., bounds.Bottom)
+	assert.Equal(t, 0., bounds.Left)
+	assert.Equal(t, 100., bounds.Right)
+
+	// A semicircle over the top reaches its radius above the center
+	p = FromSegments([]Segment{
+		{Point: point.NewPoint(0, 0), Curve: NewArc(50, 50, 0, false, true)},
+		NewSegment(100, 0),
+	}, false)
+	bounds = p.GetBounds()
+	assert.InDelta(t, -50, bounds.Top, 1e-9)
+	assert.Equal(t, 0., bounds.Bottom)
+	assert.Equal(t, 0., bounds.Left)
+	assert.Equal(t, 100., bounds.Right)
+
+	// A rotated ellipse
+	p.Segments[0].Curve = NewArc(50, 20, 45, true, false)
+	bounds = p.GetBounds()
+	top, bottom := 0., 0.
+	for i := 0; i <= 10000; i++ {
+		_, y := p.Interpolate(float64(i) / 10000)
+		top, bottom = min(top, y), max(bottom, y)
+	}
+	assert.InDelta(t, top, bounds.Top, 1e-6)
+	assert.InDelta(t, bottom, bounds.Bottom, 1e-6)
 }
 
 func TestLength(t *testing.T) {
@@ -41,11 +74,11 @@ func TestLength(t *testing.T) {
 	t.Run("path made up of lines and curves", func(t *testing.T) {
 		segments := []Segment{
 			{
-				Point: point.Point{0, 0},
-				Curve: NewCubicBezier(point.Point{25, 50}, point.Point{75, 50}),
+				Point: point.Point{X: 0, Y: 0},
+				Curve: NewCubicBezier(point.Point{X: 25, Y: 50}, point.Point{X: 75, Y: 50}),
 			},
 			{
-				Point: point.Point{100, 100},
+				Point: point.Point{X: 100, Y: 100},
 				Curve: nil,
 			},
 		}
@@ -55,11 +88,11 @@ func TestLength(t *testing.T) {
 
 		segments = []Segment{
 			{
-				Point: point.Point{0, 0},
-				Curve: NewCubicBezier(point.Point{25, 50}, point.Point{75, 50}),
+				Point: point.Point{X: 0, Y: 0},
+				Curve: NewCubicBezier(point.Point{X: 25, Y: 50}, point.Point{X: 75, Y: 50}),
 			},
 			{
-				Point: point.Point{100, 100},
+				Point: point.Point{X: 100, Y: 100},
 				Curve: nil,
 			},
 		}
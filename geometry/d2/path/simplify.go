@@ -0,0 +1,212 @@
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// Simplify removes points using the Ramer–Douglas–Peucker algorithm so that no removed point
+// was further than tolerance from the simplified path. The first and last points are kept, as
+// are the ends of curves, and closed paths keep at least three points.
+func (p *Path) Simplify(tolerance float64) *Path {
+	keep := p.fixed()
+	if tolerance <= 0 || len(keep) < 3 {
+		return p.keep(nil)
+	}
+	pts := p.Points()
+	n := len(pts)
+	if p.Closed {
+		// The point furthest from the start splits a closed path into two open halves
+		far := 0
+		for i, pt := range pts {
+			if pt.Distance(pts[0]) > pts[far].Distance(pts[0]) {
+				far = i
+			}
+		}
+		keep[far] = true
+	}
+
+	anchors := make([]int, 0)
+	for i, k := range keep {
+		if k {
+			anchors = append(anchors, i)
+		}
+	}
+	if p.Closed {
+		// Wrap back around to the first point
+		anchors = append(anchors, n)
+		pts = append(pts, pts[0])
+		keep = append(keep, true)
+	}
+	for i := 0; i < len(anchors)-1; i++ {
+		rdp(pts, keep, anchors[i], anchors[i+1], tolerance)
+	}
+	keep = keep[:n]
+
+	if p.Closed && numKept(keep) < 3 {
+		// Keep the point that is furthest from the line through the two that are left
+		a, b := anchors[0], anchors[1]
+		best, dist := -1, -1.
+		for i := range keep {
+			if !keep[i] {
+				if d := line.DistanceToPoint(pts[a].X, pts[a].Y, pts[b].X, pts[b].Y, pts[i].X, pts[i].Y); d > dist {
+					best, dist = i, d
+				}
+			}
+		}
+		if best >= 0 {
+			keep[best] = true
+		}
+	}
+	return p.keep(keep)
+}
+
+// rdp marks the points between i and j that are needed to stay within tolerance
+func rdp(pts []point.Point, keep []bool, i, j int, tolerance float64) {
+	best, dist := -1, tolerance
+	a, b := pts[i], pts[j]
+	for k := i + 1; k < j; k++ {
+		if d := line.DistanceToPoint(a.X, a.Y, b.X, b.Y, pts[k].X, pts[k].Y); d > dist {
+			best, dist = k, d
+		}
+	}
+	if best < 0 {
+		return
+	}
+	keep[best] = true
+	rdp(pts, keep, i, best, tolerance)
+	rdp(pts, keep, best, j, tolerance)
+}
+
+// SimplifyVisvalingam removes points using the Visvalingam–Whyatt algorithm. Points are removed
+// in order of the area of the triangle they make with their neighbours until every point left
+// makes a triangle with an area of at least area. This tends to give smoother results than
+// Simplify. The first and last points are kept, as are the ends of curves, and closed paths
+// keep at least three points.
+func (p *Path) SimplifyVisvalingam(area float64) *Path {
+	keep := p.fixed()
+	n := len(keep)
+	if area <= 0 || n < 3 {
+		return p.keep(nil)
+	}
+	pts := p.Points()
+	// A linked list of the points that are left
+	prev := make([]int, n)
+	next := make([]int, n)
+	for i := range pts {
+		prev[i] = i - 1
+		next[i] = i + 1
+	}
+	if p.Closed {
+		prev[0] = n - 1
+		next[n-1] = 0
+	}
+	effective := func(i int) float64 {
+		a, b, c := pts[prev[i]], pts[i], pts[next[i]]
+		return math.Abs((b.X-a.X)*(c.Y-a.Y)-(c.X-a.X)*(b.Y-a.Y)) / 2
+	}
+
+	areas := make([]float64, n)
+	q := &areaQueue{}
+	for i := range pts {
+		if !keep[i] {
+			areas[i] = effective(i)
+			heap.Push(q, areaItem{i, areas[i]})
+		}
+	}
+	removed := make([]bool, n)
+	left := n
+	for q.Len() > 0 {
+		it := heap.Pop(q).(areaItem)
+		if removed[it.i] || it.area != areas[it.i] {
+			// Stale entry for a point whose area has changed
+			continue
+		}
+		if it.area >= area || (p.Closed && left <= 3) {
+			break
+		}
+		removed[it.i] = true
+		left--
+		before, after := prev[it.i], next[it.i]
+		next[before] = after
+		prev[after] = before
+		for _, j := range []int{before, after} {
+			if keep[j] {
+				continue
+			}
+			// A point's area never drops below that of a point removed before it, so that
+			// removing a point cannot cause its neighbours to be removed out of order
+			areas[j] = math.Max(effective(j), it.area)
+			heap.Push(q, areaItem{j, areas[j]})
+		}
+	}
+	for i := range keep {
+		keep[i] = !removed[i]
+	}
+	return p.keep(keep)
+}
+
+// fixed returns which points must be kept by simplification. These are the first point, the
+// last point of an open path and the points at either end of curves.
+func (p *Path) fixed() []bool {
+	n := len(p.Segments)
+	ret := make([]bool, n)
+	for i, seg := range p.Segments {
+		if i == 0 || (!p.Closed && i == n-1) || seg.Curve != nil || p.Segments[(i+n-1)%n].Curve != nil {
+			ret[i] = true
+		}
+	}
+	return ret
+}
+
+// keep returns a path with the segments whose keep value is true, or all of them if keep is nil
+func (p *Path) keep(keep []bool) *Path {
+	segments := make([]Segment, 0, len(p.Segments))
+	for i, seg := range p.Segments {
+		if keep == nil || keep[i] {
+			segments = append(segments, seg)
+		}
+	}
+	return FromSegments(segments, p.Closed)
+}
+
+func numKept(values []bool) int {
+	ret := 0
+	for _, v := range values {
+		if v {
+			ret++
+		}
+	}
+	return ret
+}
+
+type areaItem struct {
+	i    int
+	area float64
+}
+
+// areaQueue orders points by area and then by index so results are repeatable
+type areaQueue []areaItem
+
+func (q areaQueue) Len() int { return len(q) }
+
+func (q areaQueue) Less(i, j int) bool {
+	if q[i].area != q[j].area {
+		return q[i].area < q[j].area
+	}
+	return q[i].i < q[j].i
+}
+
+func (q areaQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *areaQueue) Push(x any) { *q = append(*q, x.(areaItem)) }
+
+func (q *areaQueue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
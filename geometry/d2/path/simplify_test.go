@@ -0,0 +1,114 @@
+package path
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+// wave returns an open path along a sine wave with a little jitter
+func wave(n int) *Path {
+	coords := make([]float64, 0, 2*n)
+	for i := 0; i < n; i++ {
+		x := float64(i) / float64(n-1) * 100
+		coords = append(coords, x, 10*math.Sin(x/10)+0.05*math.Sin(float64(i)*7))
+	}
+	return NewOpenPath(coords)
+}
+
+// maxDeviation returns the furthest any point of the original is from the simplified path
+func maxDeviation(original, simplified *Path) float64 {
+	pts := simplified.Points()
+	if simplified.Closed {
+		pts = append(pts, pts[0])
+	}
+	ret := 0.
+	for _, p := range original.Points() {
+		d := math.Inf(1)
+		for i := 0; i < len(pts)-1; i++ {
+			d = math.Min(d, line.DistanceToPoint(pts[i].X, pts[i].Y, pts[i+1].X, pts[i+1].Y, p.X, p.Y))
+		}
+		ret = math.Max(ret, d)
+	}
+	return ret
+}
+
+func TestSimplify(t *testing.T) {
+	t.Run("collinear points", func(t *testing.T) {
+		p := NewOpenPath([]float64{0, 0, 1, 0, 2, 0, 3, 0, 3, 1, 3, 2})
+		assert.Equal(t, []point.Point{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 2}}, p.Simplify(0.01).Points())
+	})
+
+	t.Run("within tolerance", func(t *testing.T) {
+		p := wave(500)
+		s := p.Simplify(0.5)
+		assert.Less(t, len(s.Segments), 50)
+		assert.LessOrEqual(t, maxDeviation(p, s), 0.5)
+		assert.Equal(t, p.Segments[0].Point, s.Segments[0].Point)
+		assert.Equal(t, p.Segments[499].Point, s.Segments[len(s.Segments)-1].Point)
+		assert.Equal(t, 500, len(p.Simplify(0).Segments))
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		coords := []float64{}
+		for i := 0; i < 100; i++ {
+			a := float64(i) / 100 * 2 * math.Pi
+			coords = append(coords, 50+20*math.Cos(a), 50+20*math.Sin(a))
+		}
+		p := NewClosedPath(coords)
+		s := p.Simplify(0.5)
+		assert.True(t, s.Closed)
+		assert.Less(t, len(s.Segments), 20)
+		assert.LessOrEqual(t, maxDeviation(p, s), 0.5)
+		assert.Equal(t, p.Segments[0].Point, s.Segments[0].Point)
+
+		// Never collapses to a line
+		assert.Equal(t, 3, len(p.Simplify(100).Segments))
+	})
+
+	t.Run("keeps curves", func(t *testing.T) {
+		p := FromSegments([]Segment{
+			NewSegment(0, 0),
+			NewSegment(1, 0),
+			NewCubicBezierSegment(point.NewPoint(2, 0), point.NewPoint(3, 5), point.NewPoint(4, 5)),
+			NewSegment(5, 0),
+			NewSegment(6, 0),
+			NewSegment(7, 0),
+		}, false)
+		s := p.Simplify(0.1)
+		assert.Equal(t, []point.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 5, Y: 0}, {X: 7, Y: 0}}, s.Points())
+		assert.NotNil(t, s.Segments[1].Curve)
+	})
+}
+
+func TestSimplifyVisvalingam(t *testing.T) {
+	t.Run("collinear points", func(t *testing.T) {
+		p := NewOpenPath([]float64{0, 0, 1, 0, 2, 0, 3, 0, 3, 1, 3, 2})
+		assert.Equal(t, []point.Point{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 2}}, p.SimplifyVisvalingam(0.01).Points())
+	})
+
+	t.Run("removes small triangles first", func(t *testing.T) {
+		p := NewOpenPath([]float64{0, 0, 1, 0.1, 2, 0, 3, 3, 4, 0})
+		s := p.SimplifyVisvalingam(1)
+		assert.Equal(t, []point.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 3}, {X: 4, Y: 0}}, s.Points())
+	})
+
+	t.Run("wave", func(t *testing.T) {
+		p := wave(500)
+		s := p.SimplifyVisvalingam(0.5)
+		assert.Less(t, len(s.Segments), 60)
+		assert.Less(t, maxDeviation(p, s), 1.)
+		assert.Equal(t, p.Segments[499].Point, s.Segments[len(s.Segments)-1].Point)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		p := NewClosedPath([]float64{0, 0, 5, 0, 10, 0, 10, 10, 0, 10})
+		s := p.SimplifyVisvalingam(1)
+		assert.True(t, s.Closed)
+		assert.Equal(t, []point.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}, s.Points())
+		assert.Equal(t, 3, len(p.SimplifyVisvalingam(1000).Segments))
+	})
+}
@@ -0,0 +1,138 @@
+package path
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// CatmullRom returns a path of cubic beziers that passes through every point of the path along
+// a uniform Catmull-Rom spline. The ends of open paths are extended by repeating the end points.
+func (p *Path) CatmullRom() *Path {
+	if len(dedupe(p.Points(), p.Closed)) < 2 {
+		return FromSegments(append([]Segment{}, p.Segments...), p.Closed)
+	}
+	return NewCatmullRomAlpha(p.Points(), p.Closed, 0, 0)
+}
+
+// NewCatmullRom returns a centripetal Catmull-Rom spline through the points. Centripetal splines
+// never form cusps or loops within a span and stay close to the points where they are unevenly
+// spaced. Tension from 0 to 1 tightens the curve, with 1 giving straight lines.
+func NewCatmullRom(points []point.Point, closed bool, tension float64) *Path {
+	return NewCatmullRomAlpha(points, closed, 0.5, tension)
+}
+
+// NewCatmullRomAlpha returns a Catmull-Rom spline through the points. Alpha sets how the spacing
+// of the points affects the curve: 0 is uniform, 0.5 is centripetal and 1 is chordal. Tension
+// from 0 to 1 tightens the curve. The ends of open paths are extended by repeating the end
+// points.
+func NewCatmullRomAlpha(points []point.Point, closed bool, alpha, tension float64) *Path {
+	pts := dedupe(points, closed)
+	n := len(pts)
+	if n < 2 {
+		return polyline(pts, closed)
+	}
+	at := func(i int) point.Point {
+		if closed {
+			return pts[(i+n)%n]
+		}
+		return pts[max(0, min(n-1, i))]
+	}
+	beziers := make([][4]point.Point, 0, n)
+	for i := 0; i < spans(n, closed); i++ {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		// The knot intervals. Repeated end points use the interval of the span.
+		d1 := math.Pow(p1.Distance(p2), alpha)
+		d0 := math.Pow(p0.Distance(p1), alpha)
+		d2 := math.Pow(p2.Distance(p3), alpha)
+		if p0.Equals(p1) {
+			d0 = d1
+		}
+		if p2.Equals(p3) {
+			d2 = d1
+		}
+		chord := p2.SubtractPoint(p1)
+		m1 := p1.SubtractPoint(p0).ScalarMult(d1 / d0).SubtractPoint(p2.SubtractPoint(p0).ScalarMult(d1 / (d0 + d1))).AddPoint(chord)
+		m2 := p3.SubtractPoint(p2).ScalarMult(d1 / d2).SubtractPoint(p3.SubtractPoint(p1).ScalarMult(d1 / (d1 + d2))).AddPoint(chord)
+		scale := (1 - tension) / 3
+		beziers = append(beziers, [4]point.Point{p1, p1.AddPoint(m1.ScalarMult(scale)), p2.SubtractPoint(m2.ScalarMult(scale)), p2})
+	}
+	return fromBeziers(beziers, closed)
+}
+
+// NewBSpline returns a uniform cubic B-spline with the points as its control points. The curve
+// is smooth but only passes near the points. Open splines are clamped so that they start and
+// end at the first and last points.
+func NewBSpline(points []point.Point, closed bool) *Path {
+	pts := points
+	if !closed && len(pts) > 1 {
+		// Tripling the end points pulls the curve onto them
+		pts = append([]point.Point{pts[0], pts[0]}, pts...)
+		pts = append(pts, pts[len(pts)-1], pts[len(pts)-1])
+	}
+	n := len(pts)
+	if (closed && n < 3) || n < 2 {
+		return polyline(points, closed)
+	}
+	count := n
+	if !closed {
+		count = n - 3
+	}
+	beziers := make([][4]point.Point, 0, count)
+	for i := 0; i < count; i++ {
+		p0, p1, p2, p3 := pts[i], pts[(i+1)%n], pts[(i+2)%n], pts[(i+3)%n]
+		beziers = append(beziers, [4]point.Point{
+			p0.AddPoint(p1.ScalarMult(4)).AddPoint(p2).ScalarMult(1. / 6),
+			p1.ScalarMult(2).AddPoint(p2).ScalarMult(1. / 3),
+			p1.AddPoint(p2.ScalarMult(2)).ScalarMult(1. / 3),
+			p1.AddPoint(p2.ScalarMult(4)).AddPoint(p3).ScalarMult(1. / 6),
+		})
+	}
+	return fromBeziers(beziers, closed)
+}
+
+// NewHermite returns a cubic Hermite spline through the points with the given tangent at each
+// point. A tangent is the derivative of the curve as it passes through the point, so longer
+// tangents make the curve follow their direction for longer.
+func NewHermite(points, tangents []point.Point, closed bool) *Path {
+	n := len(points)
+	if n < 2 || len(tangents) < n {
+		return polyline(points, closed)
+	}
+	beziers := make([][4]point.Point, 0, n)
+	for i := 0; i < spans(n, closed); i++ {
+		j := (i + 1) % n
+		p1, p2 := points[i], points[j]
+		beziers = append(beziers, [4]point.Point{p1, p1.AddPoint(tangents[i].ScalarMult(1. / 3)), p2.SubtractPoint(tangents[j].ScalarMult(1. / 3)), p2})
+	}
+	return fromBeziers(beziers, closed)
+}
+
+// spans returns the number of curves between n points
+func spans(n int, closed bool) int {
+	if closed {
+		return n
+	}
+	return n - 1
+}
+
+// fromBeziers joins beziers that each start where the previous one ended into a path
+func fromBeziers(beziers [][4]point.Point, closed bool) *Path {
+	segments := make([]Segment, 0, len(beziers)+1)
+	for _, b := range beziers {
+		segments = append(segments, NewCubicBezierSegment(b[0], b[1], b[2]))
+	}
+	if !closed {
+		end := beziers[len(beziers)-1][3]
+		segments = append(segments, Segment{Point: end})
+	}
+	return FromSegments(segments, closed)
+}
+
+func polyline(points []point.Point, closed bool) *Path {
+	segments := make([]Segment, len(points))
+	for i, p := range points {
+		segments[i] = Segment{Point: p}
+	}
+	return FromSegments(segments, closed)
+}
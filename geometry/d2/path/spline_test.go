@@ -0,0 +1,99 @@
+package path
+
+import (
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+var zigzag = []point.Point{{X: 0, Y: 0}, {X: 1, Y: 5}, {X: 20, Y: 0}, {X: 21, Y: 5}, {X: 40, Y: 0}}
+
+func assertPoint(t *testing.T, expected point.Point, x, y float64) {
+	assert.InDelta(t, expected.X, x, 1e-9)
+	assert.InDelta(t, expected.Y, y, 1e-9)
+}
+
+func TestCatmullRomSplines(t *testing.T) {
+	t.Run("passes through the points", func(t *testing.T) {
+		for _, tension := range []float64{0, 0.5} {
+			p := NewCatmullRom(zigzag, false, tension)
+			assert.Equal(t, 5, len(p.Segments))
+			for i, pt := range zigzag {
+				x, y := p.Interpolate(float64(i))
+				assertPoint(t, pt, x, y)
+			}
+		}
+	})
+
+	t.Run("uniform matches the path method", func(t *testing.T) {
+		p := NewOpenPath([]float64{0, 0, 10, 5, 20, 0, 30, 5})
+		assert.Equal(t, p.CatmullRom(), NewCatmullRomAlpha(p.Points(), false, 0, 0))
+	})
+
+	t.Run("centripetal stays closer to unevenly spaced points", func(t *testing.T) {
+		uniform := NewCatmullRomAlpha(zigzag, false, 0, 0)
+		centripetal := NewCatmullRom(zigzag, false, 0)
+		// The short first span overshoots with the uniform spline
+		maxY := func(p *Path) float64 {
+			ret := 0.
+			for t := 0.; t <= 1; t += 0.01 {
+				_, y := p.Interpolate(t)
+				ret = max(ret, y)
+			}
+			return ret
+		}
+		assert.Less(t, maxY(centripetal), maxY(uniform))
+	})
+
+	t.Run("full tension gives straight lines", func(t *testing.T) {
+		p := NewCatmullRom(zigzag, false, 1)
+		x, y := p.Interpolate(1.5)
+		assertPoint(t, point.NewPoint(10.5, 2.5), x, y)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		p := NewCatmullRom(zigzag, true, 0)
+		assert.True(t, p.Closed)
+		assert.Equal(t, 5, len(p.Segments))
+		x, y := p.Interpolate(5)
+		assertPoint(t, zigzag[0], x, y)
+	})
+}
+
+func TestBSpline(t *testing.T) {
+	t.Run("open is clamped to the ends", func(t *testing.T) {
+		p := NewBSpline(zigzag, false)
+		assert.Equal(t, zigzag[0], p.Segments[0].Point)
+		assert.Equal(t, zigzag[4], p.Segments[len(p.Segments)-1].Point)
+		// Smooth joins
+		for i := 1; i < len(p.Segments)-1; i++ {
+			in := p.Segments[i].Point.SubtractPoint(p.Segments[i-1].Curve.C2)
+			out := p.Segments[i].Curve.C1.SubtractPoint(p.Segments[i].Point)
+			assert.InDelta(t, 0, in.X*out.Y-in.Y*out.X, 1e-9)
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		square := []point.Point{{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 6, Y: 6}, {X: 0, Y: 6}}
+		p := NewBSpline(square, true)
+		assert.True(t, p.Closed)
+		assert.Equal(t, 4, len(p.Segments))
+		// Each span starts at the B-spline point of its corner
+		assert.Equal(t, point.NewPoint(5, 1), p.Segments[0].Point)
+	})
+}
+
+func TestHermite(t *testing.T) {
+	pts := []point.Point{{X: 0, Y: 0}, {X: 10, Y: 0}}
+	tangents := []point.Point{{X: 0, Y: 30}, {X: 0, Y: -30}}
+	p := NewHermite(pts, tangents, false)
+	assert.Equal(t, point.NewPoint(0, 10), p.Segments[0].Curve.C1)
+	assert.Equal(t, point.NewPoint(10, 10), p.Segments[0].Curve.C2)
+	x, y := p.Interpolate(0.5)
+	assertPoint(t, point.NewPoint(5, 7.5), x, y)
+
+	closed := NewHermite(pts, tangents, true)
+	assert.Equal(t, 2, len(closed.Segments))
+	assert.NotNil(t, closed.Segments[1].Curve)
+}
@@ -0,0 +1,299 @@
+package path
+
+import (
+	"math"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// cuspTolerance is how slow a curve must be, relative to the size of its coefficients, for it
+// to count as stopped
+const cuspTolerance = 1e-6
+
+// TangentAt returns the unit direction of the path at t, where t is the index of a segment plus
+// how far along it to go, as in Interpolate. At a vertex it is the direction of the segment that
+// starts there. At a cusp it is the direction the path leaves in. It is zero where the path has
+// no length.
+func (p *Path) TangentAt(t float64) point.Point {
+	seg, to, u, ok := p.at(t)
+	if !ok {
+		return point.Point{}
+	}
+	return seg.TangentAt(to, u)
+}
+
+// NormalAt returns the unit normal of the path at t. It is the tangent turned a quarter turn
+// anticlockwise on screen, so it points out of clockwise paths.
+func (p *Path) NormalAt(t float64) point.Point {
+	return p.TangentAt(t).Normal()
+}
+
+// CurvatureAt returns the signed curvature of the path at t, which is one over the radius of the
+// circle that best fits the path there. It is positive where the path turns clockwise on screen,
+// zero along lines and infinite at cusps.
+func (p *Path) CurvatureAt(t float64) float64 {
+	seg, to, u, ok := p.at(t)
+	if !ok {
+		return 0
+	}
+	return seg.CurvatureAt(to, u)
+}
+
+// Inflections returns the values of t where the curvature of the path changes sign within a
+// curve, in order. Corners between segments are not included.
+func (p *Path) Inflections() []float64 {
+	return p.spans(func(s Segment, to point.Point) []float64 { return s.Inflections(to) })
+}
+
+// Cusps returns the values of t where a curve of the path stops and turns back on itself, in
+// order. Corners between segments are not included.
+func (p *Path) Cusps() []float64 {
+	return p.spans(func(s Segment, to point.Point) []float64 { return s.Cusps(to) })
+}
+
+// at returns the segment t falls on, the point it runs to and how far along it t is. Closed
+// paths wrap around and open paths are clamped to their ends. ok is false if the path has no
+// segments to run along.
+func (p *Path) at(t float64) (seg Segment, to point.Point, u float64, ok bool) {
+	n := len(p.Segments)
+	spans := n - 1
+	if p.Closed {
+		spans = n
+	}
+	if n < 2 || math.IsNaN(t) {
+		return Segment{}, point.Point{}, 0, false
+	}
+	i := int(math.Floor(t))
+	u = t - float64(i)
+	if p.Closed {
+		i = ((i % n) + n) % n
+	} else if i < 0 {
+		i, u = 0, 0
+	} else if i >= spans {
+		i, u = spans-1, 1
+	}
+	return p.Segments[i], p.Segments[(i+1)%n].Point, u, true
+}
+
+// spans collects the parameters found by find on each segment, offset to the parameters of the
+// path
+func (p *Path) spans(find func(s Segment, to point.Point) []float64) []float64 {
+	ret := make([]float64, 0)
+	n := len(p.Segments)
+	for i, seg := range p.Segments {
+		if !p.Closed && i == n-1 {
+			break
+		}
+		for _, t := range find(seg, p.Segments[(i+1)%n].Point) {
+			ret = append(ret, float64(i)+t)
+		}
+	}
+	return ret
+}
+
+// TangentAt returns the unit direction of the segment ending at to at t
+func (s Segment) TangentAt(to point.Point, t float64) point.Point {
+	d1, d2, d3 := s.derivatives(to, t)
+	// Where the curve stops the lowest derivative that does not vanish gives its direction
+	for _, d := range []point.Point{d1, d2, d3} {
+		if d.Magnitude() > 0 {
+			return d.Normalize()
+		}
+	}
+	return point.Point{}
+}
+
+// NormalAt returns the unit normal of the segment ending at to at t
+func (s Segment) NormalAt(to point.Point, t float64) point.Point {
+	return s.TangentAt(to, t).Normal()
+}
+
+// CurvatureAt returns the signed curvature of the segment ending at to at t
+func (s Segment) CurvatureAt(to point.Point, t float64) float64 {
+	d1, d2, _ := s.derivatives(to, t)
+	speed := d1.Magnitude()
+	if speed == 0 {
+		if d2.Magnitude() > 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return cross(d1, d2) / (speed * speed * speed)
+}
+
+// Inflections returns the values of t strictly between 0 and 1 where the curvature of the
+// segment ending at to changes sign. Only cubic beziers can have them.
+func (s Segment) Inflections(to point.Point) []float64 {
+	c, ok := s.coefficients(to)
+	if !ok {
+		return []float64{}
+	}
+	// The cross product of the first and second derivatives is this quadratic
+	roots := quadraticRoots(6*cross(c[1], c[2]), 6*cross(c[0], c[2]), 2*cross(c[0], c[1]))
+	ret := make([]float64, 0, len(roots))
+	for _, t := range roots {
+		// At a cusp the curvature is infinite rather than zero
+		if !s.stopped(c, t) {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+// Cusps returns the values of t strictly between 0 and 1 where the segment ending at to stops
+// and turns back on itself. Only beziers can have them.
+func (s Segment) Cusps(to point.Point) []float64 {
+	c, ok := s.coefficients(to)
+	if !ok {
+		return []float64{}
+	}
+	// The curve can only stop where both coordinates of its derivative are zero
+	roots := quadraticRoots(3*c[2].X, 2*c[1].X, c[0].X)
+	roots = append(roots, quadraticRoots(3*c[2].Y, 2*c[1].Y, c[0].Y)...)
+	sort.Float64s(roots)
+	ret := make([]float64, 0, len(roots))
+	for _, t := range roots {
+		if s.stopped(c, t) && (len(ret) == 0 || t-ret[len(ret)-1] > cuspTolerance) {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+// extrema returns the values of t strictly between 0 and 1 where the segment ending at to turns
+// back along x or y, which are where it can reach beyond the bounds of its end points
+func (s Segment) extrema(to point.Point) []float64 {
+	if c, ok := s.coefficients(to); ok {
+		ret := quadraticRoots(3*c[2].X, 2*c[1].X, c[0].X)
+		ret = append(ret, quadraticRoots(3*c[2].Y, 2*c[1].Y, c[0].Y)...)
+		sort.Float64s(ret)
+		return ret
+	}
+	if s.Curve == nil || s.Curve.Arc == nil {
+		return []float64{}
+	}
+	e, ok := s.Curve.Arc.ellipse(s.Point, to)
+	if !ok {
+		return []float64{}
+	}
+	cos, sin := math.Cos(e.phi), math.Sin(e.phi)
+	// The angles where the derivatives of x and y around the ellipse are zero
+	x := math.Atan2(-e.ry*sin, e.rx*cos)
+	y := math.Atan2(e.ry*cos, e.rx*sin)
+	ret := make([]float64, 0, 4)
+	for _, theta := range []float64{x, x + math.Pi, y, y + math.Pi} {
+		// How far theta is past the start in the direction of the arc
+		d := math.Mod(theta-e.start, 2*math.Pi)
+		if d < 0 {
+			d += 2 * math.Pi
+		}
+		if e.delta < 0 {
+			d -= 2 * math.Pi
+		}
+		if t := d / e.delta; t > 0 && t < 1 {
+			ret = append(ret, t)
+		}
+	}
+	sort.Float64s(ret)
+	return ret
+}
+
+// coefficients returns the coefficients of t, t² and t³ of the curve's polynomial. ok is false
+// for lines and arcs.
+func (s Segment) coefficients(to point.Point) ([3]point.Point, bool) {
+	if s.Curve == nil {
+		return [3]point.Point{}, false
+	}
+	c1, c2, ok := s.Curve.CubicControls(s.Point, to)
+	if !ok {
+		return [3]point.Point{}, false
+	}
+	p0 := s.Point
+	return [3]point.Point{
+		c1.SubtractPoint(p0).ScalarMult(3),
+		c2.SubtractPoint(c1.ScalarMult(2)).AddPoint(p0).ScalarMult(3),
+		to.SubtractPoint(c2.ScalarMult(3)).AddPoint(c1.ScalarMult(3)).SubtractPoint(p0),
+	}, true
+}
+
+// stopped returns true if the first derivative of the polynomial is close to zero at t
+func (s Segment) stopped(c [3]point.Point, t float64) bool {
+	scale := math.Max(c[0].Magnitude(), math.Max(c[1].Magnitude(), c[2].Magnitude()))
+	d1 := c[0].AddPoint(c[1].ScalarMult(2 * t)).AddPoint(c[2].ScalarMult(3 * t * t))
+	return d1.Magnitude() <= cuspTolerance*scale
+}
+
+// derivatives returns the first three derivatives of the segment ending at to with respect to t
+func (s Segment) derivatives(to point.Point, t float64) (d1, d2, d3 point.Point) {
+	if c, ok := s.coefficients(to); ok {
+		d1 = c[0].AddPoint(c[1].ScalarMult(2 * t)).AddPoint(c[2].ScalarMult(3 * t * t))
+		d2 = c[1].ScalarMult(2).AddPoint(c[2].ScalarMult(6 * t))
+		d3 = c[2].ScalarMult(6)
+		return d1, d2, d3
+	}
+	if s.Curve != nil && s.Curve.Arc != nil {
+		if e, ok := s.Curve.Arc.ellipse(s.Point, to); ok {
+			return e.derivatives(t)
+		}
+	}
+	return to.SubtractPoint(s.Point), point.Point{}, point.Point{}
+}
+
+// derivatives returns the first three derivatives of the arc with respect to the fraction of it
+// travelled
+func (e ellipse) derivatives(t float64) (d1, d2, d3 point.Point) {
+	theta := e.start + t*e.delta
+	cos, sin := math.Cos(theta), math.Sin(theta)
+	// The derivatives of the unrotated ellipse cycle through sin and cos
+	rotate := func(x, y, scale float64) point.Point {
+		c, s := math.Cos(e.phi), math.Sin(e.phi)
+		return point.NewPoint((c*x-s*y)*scale, (s*x+c*y)*scale)
+	}
+	d1 = rotate(-e.rx*sin, e.ry*cos, e.delta)
+	d2 = rotate(-e.rx*cos, -e.ry*sin, e.delta*e.delta)
+	d3 = rotate(e.rx*sin, -e.ry*cos, e.delta*e.delta*e.delta)
+	return d1, d2, d3
+}
+
+func cross(a, b point.Point) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
+
+// quadraticRoots returns the real roots of a t² + b t + c strictly between 0 and 1, in order.
+// A repeated root is returned once.
+func quadraticRoots(a, b, c float64) []float64 {
+	var roots []float64
+	scale := math.Max(math.Abs(a), math.Max(math.Abs(b), math.Abs(c)))
+	if scale == 0 {
+		return []float64{}
+	}
+	if math.Abs(a) <= 1e-12*scale {
+		if b != 0 {
+			roots = append(roots, -c/b)
+		}
+	} else {
+		disc := b*b - 4*a*c
+		if disc < 0 && disc > -1e-12*scale*scale {
+			disc = 0
+		}
+		if disc == 0 {
+			roots = append(roots, -b/(2*a))
+		} else if disc > 0 {
+			// Avoid cancellation by finding the larger root first
+			q := -(b + math.Copysign(math.Sqrt(disc), b)) / 2
+			roots = append(roots, q/a)
+			if q != 0 {
+				roots = append(roots, c/q)
+			}
+		}
+	}
+	sort.Float64s(roots)
+	ret := make([]float64, 0, len(roots))
+	for _, t := range roots {
+		if t > 0 && t < 1 {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,110 @@
+package path
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertPointInDelta(t *testing.T, expected, actual point.Point, delta float64) {
+	t.Helper()
+	assert.InDelta(t, expected.X, actual.X, delta)
+	assert.InDelta(t, expected.Y, actual.Y, delta)
+}
+
+func TestTangentLine(t *testing.T) {
+	p := NewOpenPath([]float64{0, 0, 10, 0, 10, 10})
+	assertPointInDelta(t, point.NewPoint(1, 0), p.TangentAt(0.5), 1e-9)
+	assertPointInDelta(t, point.NewPoint(0, -1), p.NormalAt(0.5), 1e-9)
+	assert.Equal(t, 0., p.CurvatureAt(0.5))
+
+	// A vertex takes the direction of the segment that starts there
+	assertPointInDelta(t, point.NewPoint(0, 1), p.TangentAt(1), 1e-9)
+	// Open paths are clamped to their ends
+	assertPointInDelta(t, point.NewPoint(0, 1), p.TangentAt(5), 1e-9)
+	assertPointInDelta(t, point.NewPoint(1, 0), p.TangentAt(-1), 1e-9)
+
+	// Closed paths wrap around
+	square := NewClosedPath([]float64{0, 0, 10, 0, 10, 10, 0, 10})
+	assertPointInDelta(t, point.NewPoint(0, -1), square.TangentAt(3.5), 1e-9)
+	assertPointInDelta(t, square.TangentAt(0.5), square.TangentAt(4.5), 1e-9)
+	assertPointInDelta(t, square.TangentAt(3.5), square.TangentAt(-0.5), 1e-9)
+	// The normal points out of a clockwise path
+	assertPointInDelta(t, point.NewPoint(0, -1), square.NormalAt(0.5), 1e-9)
+
+	assert.Equal(t, point.Point{}, NewOpenPath([]float64{0, 0}).TangentAt(0))
+}
+
+func TestCurvatureArc(t *testing.T) {
+	// A semicircle of radius 50 over the top from 0, 0 to 100, 0
+	p := FromSegments([]Segment{
+		{Point: point.NewPoint(0, 0), Curve: NewArc(50, 50, 0, false, true)},
+		{Point: point.NewPoint(100, 0)},
+	}, false)
+	assertPointInDelta(t, point.NewPoint(0, -1), p.TangentAt(0), 1e-9)
+	assertPointInDelta(t, point.NewPoint(1, 0), p.TangentAt(0.5), 1e-9)
+	assertPointInDelta(t, point.NewPoint(0, 1), p.TangentAt(1), 1e-9)
+	for _, u := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		assert.InDelta(t, 1./50, p.CurvatureAt(u), 1e-12)
+	}
+
+	// Sweeping the other way turns anticlockwise
+	p.Segments[0].Curve = NewArc(50, 50, 0, false, false)
+	assert.InDelta(t, -1./50, p.CurvatureAt(0.5), 1e-12)
+	assert.Empty(t, p.Inflections())
+	assert.Empty(t, p.Cusps())
+}
+
+func TestCurvatureQuadratic(t *testing.T) {
+	// The parabola y = x² from -1 to 1
+	p := FromSegments([]Segment{
+		{Point: point.NewPoint(-1, 1), Curve: NewQuadraticBezier(point.NewPoint(0, -1))},
+		{Point: point.NewPoint(1, 1)},
+	}, false)
+	assertPointInDelta(t, point.NewPoint(1, 0), p.TangentAt(0.5), 1e-9)
+	assert.InDelta(t, 2, p.CurvatureAt(0.5), 1e-9)
+	// At x = 1 the curvature of y = x² is 2 / 5^(3/2)
+	assert.InDelta(t, 2/math.Pow(5, 1.5), p.CurvatureAt(1), 1e-9)
+}
+
+func TestInflections(t *testing.T) {
+	s := NewCubicBezierSegment(point.NewPoint(0, 0), point.NewPoint(50, -50), point.NewPoint(50, 50))
+	to := point.NewPoint(100, 0)
+	ts := s.Inflections(to)
+	if assert.Len(t, ts, 1) {
+		assert.InDelta(t, 0.5, ts[0], 1e-9)
+	}
+	assert.Greater(t, s.CurvatureAt(to, 0.4), 0.)
+	assert.Less(t, s.CurvatureAt(to, 0.6), 0.)
+	assert.Empty(t, s.Cusps(to))
+
+	// Path parameters are offset by the segment index
+	p := FromSegments([]Segment{NewSegment(-100, 0), s, {Point: to}}, false)
+	ts = p.Inflections()
+	if assert.Len(t, ts, 1) {
+		assert.InDelta(t, 1.5, ts[0], 1e-9)
+	}
+
+	// A simple arch has none
+	arch := NewCubicBezierSegment(point.NewPoint(0, 0), point.NewPoint(0, -50), point.NewPoint(100, -50))
+	assert.Empty(t, arch.Inflections(to))
+}
+
+func TestCusps(t *testing.T) {
+	s := NewCubicBezierSegment(point.NewPoint(0, 0), point.NewPoint(100, 100), point.NewPoint(0, 100))
+	to := point.NewPoint(100, 0)
+	ts := s.Cusps(to)
+	if assert.Len(t, ts, 1) {
+		assert.InDelta(t, 0.5, ts[0], 1e-9)
+	}
+	assert.Empty(t, s.Inflections(to))
+	assert.True(t, math.IsInf(s.CurvatureAt(to, 0.5), 1))
+	// The tangent at the cusp is the direction the curve leaves in
+	assertPointInDelta(t, point.NewPoint(0, -1), s.TangentAt(to, 0.5), 1e-9)
+
+	// A loop crosses itself without stopping
+	loop := NewCubicBezierSegment(point.NewPoint(0, 0), point.NewPoint(150, 100), point.NewPoint(-50, 100))
+	assert.Empty(t, loop.Cusps(to))
+}
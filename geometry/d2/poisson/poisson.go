@@ -0,0 +1,164 @@
+package poisson
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/srmullen/godraw-lib/util"
+)
+
+// DefaultAttempts is the number of candidates tried around a point before giving up on it
+const DefaultAttempts = 30
+
+// Options control the spacing of the points generated by Sample.
+type Options struct {
+	// Radius is the minimum distance between points where the density is highest
+	Radius float64
+	// MaxRadius is the minimum distance between points where the density is zero.
+	// Defaults to Radius, which spaces the points evenly.
+	MaxRadius float64
+	// Density returns a value from 0 to 1 at x, y that sets the spacing between Radius and
+	// MaxRadius. Defaults to 1 everywhere.
+	Density func(x, y float64) float64
+	// Attempts is the number of candidates tried around each point. Defaults to DefaultAttempts.
+	Attempts int
+	// Rand is the source of randomness. Defaults to a source with seed 0 so that the same
+	// options always give the same points.
+	Rand *rand.Rand
+}
+
+func (o Options) maxRadius() float64 {
+	return math.Max(o.MaxRadius, o.Radius)
+}
+
+func (o Options) attempts() int {
+	if o.Attempts <= 0 {
+		return DefaultAttempts
+	}
+	return o.Attempts
+}
+
+func (o Options) rand() *rand.Rand {
+	if o.Rand == nil {
+		return rand.New(rand.NewSource(0))
+	}
+	return o.Rand
+}
+
+// radius returns the spacing around x, y
+func (o Options) radius(x, y float64) float64 {
+	if o.Density == nil {
+		return o.Radius
+	}
+	d := util.Clamp(o.Density(x, y), 0, 1)
+	return o.maxRadius() + d*(o.Radius-o.maxRadius())
+}
+
+// Sample returns points inside the bounds that are no closer to each other than the radius,
+// using Bridson's algorithm. The points are spread without any visible pattern.
+func Sample(opts Options, b bounds.Bounds) []point.Point {
+	return sample(opts, b, b.Contains)
+}
+
+// SamplePolygon returns points inside the polygon and outside of the holes that are no closer
+// to each other than the radius.
+func SamplePolygon(opts Options, poly *polygon.Polygon, holes ...*polygon.Polygon) []point.Point {
+	shape := polygon.NewPolygonWithHoles(poly, holes...)
+	return sample(opts, *poly.GetBounds(), shape.ContainsPoint)
+}
+
+// grid is a background grid with cells small enough that each holds at most one point at the
+// smallest radius
+type grid struct {
+	b     bounds.Bounds
+	size  float64
+	cols  int
+	rows  int
+	cells [][]int
+}
+
+func (g *grid) cell(p point.Point) (int, int) {
+	col := int((p.X - g.b.Left) / g.size)
+	row := int((p.Y - g.b.Top) / g.size)
+	return min(max(col, 0), g.cols-1), min(max(row, 0), g.rows-1)
+}
+
+func sample(opts Options, b bounds.Bounds, inside func(x, y float64) bool) []point.Point {
+	if opts.Radius <= 0 || b.Width() <= 0 || b.Height() <= 0 {
+		return nil
+	}
+	rng := opts.rand()
+	attempts := opts.attempts()
+	maxRadius := opts.maxRadius()
+
+	size := opts.Radius / math.Sqrt2
+	g := &grid{b: b, size: size, cols: int(math.Ceil(b.Width()/size)) + 1, rows: int(math.Ceil(b.Height()/size)) + 1}
+	g.cells = make([][]int, g.cols*g.rows)
+	reach := int(math.Ceil(maxRadius / size))
+
+	var points []point.Point
+	var radii []float64
+	fits := func(p point.Point, r float64) bool {
+		col, row := g.cell(p)
+		for y := max(row-reach, 0); y <= min(row+reach, g.rows-1); y++ {
+			for x := max(col-reach, 0); x <= min(col+reach, g.cols-1); x++ {
+				for _, i := range g.cells[y*g.cols+x] {
+					// Neighbours with different radii must be at least their average apart
+					if p.Distance(points[i]) < (r+radii[i])/2 {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+	add := func(p point.Point, r float64) {
+		col, row := g.cell(p)
+		g.cells[row*g.cols+col] = append(g.cells[row*g.cols+col], len(points))
+		points = append(points, p)
+		radii = append(radii, r)
+	}
+
+	// Start from a random point inside the shape
+	for i := 0; i < 1000; i++ {
+		p := point.NewPoint(b.Left+rng.Float64()*b.Width(), b.Top+rng.Float64()*b.Height())
+		if inside(p.X, p.Y) {
+			add(p, opts.radius(p.X, p.Y))
+			break
+		}
+	}
+
+	active := make([]int, len(points))
+	for i := range active {
+		active[i] = i
+	}
+	for len(active) > 0 {
+		k := rng.Intn(len(active))
+		p := points[active[k]]
+		r := radii[active[k]]
+		found := false
+		for i := 0; i < attempts; i++ {
+			// Candidates are in the ring between r and 2r around p
+			angle := rng.Float64() * 2 * math.Pi
+			c := p.AddPoint(point.NewPointFromAngle(angle, r*(1+rng.Float64())))
+			if !inside(c.X, c.Y) {
+				continue
+			}
+			rc := opts.radius(c.X, c.Y)
+			if fits(c, rc) {
+				add(c, rc)
+				active = append(active, len(points)-1)
+				found = true
+				break
+			}
+		}
+		if !found {
+			active[k] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
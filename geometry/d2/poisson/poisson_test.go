@@ -0,0 +1,73 @@
+package poisson
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func minDistance(points []point.Point) float64 {
+	ret := 1e18
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			if d := points[i].Distance(points[j]); d < ret {
+				ret = d
+			}
+		}
+	}
+	return ret
+}
+
+func TestSample(t *testing.T) {
+	b := bounds.NewBounds(0, 100, 100, 0)
+
+	t.Run("fixed radius", func(t *testing.T) {
+		points := Sample(Options{Radius: 5}, b)
+		assert.True(t, minDistance(points) >= 5)
+		// Bridson sampling packs roughly one point per 1.5 r^2 to 2 r^2
+		assert.True(t, len(points) > 250)
+		for _, p := range points {
+			assert.True(t, b.Contains(p.X, p.Y))
+		}
+	})
+
+	t.Run("seedable", func(t *testing.T) {
+		a := Sample(Options{Radius: 5, Rand: rand.New(rand.NewSource(7))}, b)
+		assert.Equal(t, a, Sample(Options{Radius: 5, Rand: rand.New(rand.NewSource(7))}, b))
+		assert.NotEqual(t, a, Sample(Options{Radius: 5, Rand: rand.New(rand.NewSource(8))}, b))
+		assert.Equal(t, Sample(Options{Radius: 5}, b), Sample(Options{Radius: 5}, b))
+	})
+
+	t.Run("density", func(t *testing.T) {
+		// Dense on the left, sparse on the right
+		opts := Options{Radius: 2, MaxRadius: 8, Density: func(x, y float64) float64 {
+			return 1 - x/100
+		}}
+		points := Sample(opts, b)
+		left, right := 0, 0
+		for _, p := range points {
+			if p.X < 50 {
+				left++
+			} else {
+				right++
+			}
+		}
+		assert.True(t, left > 2*right)
+		assert.True(t, minDistance(points) >= 2)
+	})
+}
+
+func TestSamplePolygon(t *testing.T) {
+	star := polygon.NewStar(50, 50, 50, 25, 5)
+	hole := polygon.NewNgon(8, 50, 50, 10)
+	points := SamplePolygon(Options{Radius: 3}, star, hole)
+	assert.True(t, len(points) > 50)
+	for _, p := range points {
+		assert.True(t, star.ContainsPoint(p.X, p.Y))
+		assert.False(t, hole.ContainsPoint(p.X, p.Y))
+	}
+}
@@ -0,0 +1,75 @@
+package polygon
+
+import (
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+
+	"github.com/engelsjk/polygol"
+)
+
+// DefaultTolerance is the maximum distance between a curve and the lines that replace it when a
+// path with curves is converted to a polygon.
+const DefaultTolerance = 0.1
+
+type operation func(geom polygol.Geom, moreGeoms ...polygol.Geom) (polygol.Geom, error)
+
+func apply(op operation, geom polygol.Geom, others []polygol.Geom) (MultiPolygon, error) {
+	multipolygons, err := op(geom, others...)
+	if err != nil {
+		return nil, err
+	}
+	return FromGeom(multipolygons), nil
+}
+
+func (p *Polygon) Union(others ...*Polygon) (MultiPolygon, error) {
+	return apply(polygol.Union, p.ToGeom(), ToGeoms(others...))
+}
+
+func (p *Polygon) Intersection(others ...*Polygon) (MultiPolygon, error) {
+	return apply(polygol.Intersection, p.ToGeom(), ToGeoms(others...))
+}
+
+func (p *Polygon) Difference(others ...*Polygon) (MultiPolygon, error) {
+	return apply(polygol.Difference, p.ToGeom(), ToGeoms(others...))
+}
+
+func (p *Polygon) XOR(others ...*Polygon) (MultiPolygon, error) {
+	return apply(polygol.XOR, p.ToGeom(), ToGeoms(others...))
+}
+
+// FromPath creates a polygon from the path. Curves are replaced by lines that are within the
+// tolerance of them.
+func FromPath(p *path.Path, tolerance float64) *Polygon {
+	return NewPolygon(Points(p.Flatten(tolerance).Points()))
+}
+
+func pathGeoms(tolerance float64, paths []*path.Path) []polygol.Geom {
+	geoms := make([]polygol.Geom, len(paths))
+	for i, p := range paths {
+		geoms[i] = FromPath(p, tolerance).ToGeom()
+	}
+	return geoms
+}
+
+// UnionPaths returns the area covered by any of the paths. Paths are treated as closed and curves
+// are flattened to within the tolerance.
+func UnionPaths(tolerance float64, p *path.Path, others ...*path.Path) (MultiPolygon, error) {
+	return apply(polygol.Union, FromPath(p, tolerance).ToGeom(), pathGeoms(tolerance, others))
+}
+
+// IntersectionPaths returns the area covered by all of the paths. Paths are treated as closed and
+// curves are flattened to within the tolerance.
+func IntersectionPaths(tolerance float64, p *path.Path, others ...*path.Path) (MultiPolygon, error) {
+	return apply(polygol.Intersection, FromPath(p, tolerance).ToGeom(), pathGeoms(tolerance, others))
+}
+
+// DifferencePaths returns the area of p that is not covered by any of the others. Paths are
+// treated as closed and curves are flattened to within the tolerance.
+func DifferencePaths(tolerance float64, p *path.Path, others ...*path.Path) (MultiPolygon, error) {
+	return apply(polygol.Difference, FromPath(p, tolerance).ToGeom(), pathGeoms(tolerance, others))
+}
+
+// XORPaths returns the area covered by an odd number of the paths. Paths are treated as closed
+// and curves are flattened to within the tolerance.
+func XORPaths(tolerance float64, p *path.Path, others ...*path.Path) (MultiPolygon, error) {
+	return apply(polygol.XOR, FromPath(p, tolerance).ToGeom(), pathGeoms(tolerance, others))
+}
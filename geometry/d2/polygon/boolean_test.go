@@ -0,0 +1,56 @@
+package polygon
+
+import (
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoolean(t *testing.T) {
+	t.Run("difference keeps holes", func(t *testing.T) {
+		outer := NewRectangle(0, 0, 10, 10)
+		inner := NewRectangle(4, 4, 2, 2)
+		result, err := outer.Difference(inner.Polygon)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, 4, len(result[0].Outer.Segments))
+		assert.Equal(t, 1, len(result[0].Holes))
+		assert.Equal(t, 4, len(result[0].Holes[0].Segments))
+	})
+
+	t.Run("union", func(t *testing.T) {
+		a := NewRectangle(0, 0, 10, 10)
+		b := NewRectangle(5, 5, 10, 10)
+		c := NewRectangle(20, 20, 1, 1)
+		result, err := a.Union(b.Polygon, c.Polygon)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(result))
+		assert.Equal(t, 8, len(result[0].Outer.Segments))
+		assert.Equal(t, 2, len(result.Polygons()))
+	})
+
+	t.Run("curved paths are flattened", func(t *testing.T) {
+		curve := path.FromSegments([]path.Segment{
+			path.NewCubicBezierSegment(point.NewPoint(0, 0), point.NewPoint(0, -10), point.NewPoint(10, -10)),
+			path.NewSegment(10, 0),
+		}, true)
+		square := path.NewClosedPath([]float64{0, -20, 10, -20, 10, -5, 0, -5})
+		result, err := IntersectionPaths(0.01, curve, square)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		for _, seg := range result[0].Outer.Segments {
+			assert.Nil(t, seg.Curve)
+			assert.True(t, seg.Y <= -5)
+		}
+		assert.True(t, len(result[0].Outer.Segments) > 4)
+	})
+
+	t.Run("geom round trip", func(t *testing.T) {
+		donut := MultiPolygon{NewPolygonWithHoles(NewRectangle(0, 0, 10, 10).Polygon, NewRectangle(4, 4, 2, 2).Polygon)}
+		result := FromGeom(donut.ToGeom())
+		assert.Equal(t, donut[0].Outer.Points(), result[0].Outer.Points())
+		assert.Equal(t, donut[0].Holes[0].Points(), result[0].Holes[0].Points())
+	})
+}
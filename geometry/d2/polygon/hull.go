@@ -0,0 +1,145 @@
+package polygon
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/circle"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// ConvexHull returns the smallest convex polygon that contains all of the points. The hull turns
+// clockwise on screen and has no collinear vertices.
+func ConvexHull(points Points) *Polygon {
+	return NewPolygon(Points(hull(points)))
+}
+
+// PathConvexHull returns the convex hull of the path's points and the control points of its
+// curves. The curves are always inside the hull of their control points.
+func PathConvexHull(p *path.Path) *Polygon {
+	return ConvexHull(controlPoints(p))
+}
+
+// hull returns the convex hull using Andrew's monotone chain
+func hull(points []point.Point) []point.Point {
+	pts := append([]point.Point{}, points...)
+	sort.Slice(pts, func(i, j int) bool {
+		return pts[i].X < pts[j].X || (pts[i].X == pts[j].X && pts[i].Y < pts[j].Y)
+	})
+	if len(pts) < 3 {
+		return pts
+	}
+	ret := make([]point.Point, 0, 2*len(pts))
+	// Lower chain left to right, then upper chain right to left
+	for pass := 0; pass < 2; pass++ {
+		start := len(ret)
+		for _, p := range pts {
+			for len(ret) >= start+2 && cross(ret[len(ret)-2], ret[len(ret)-1], p) <= 0 {
+				ret = ret[:len(ret)-1]
+			}
+			ret = append(ret, p)
+		}
+		// The last point is the first point of the next chain
+		ret = ret[:len(ret)-1]
+		for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+			pts[i], pts[j] = pts[j], pts[i]
+		}
+	}
+	return ret
+}
+
+func controlPoints(p *path.Path) []point.Point {
+	var ret []point.Point
+	for _, seg := range p.Segments {
+		ret = append(ret, seg.Point)
+		if seg.Curve == nil {
+			continue
+		}
+		if seg.Curve.CubicBezier != nil {
+			ret = append(ret, seg.Curve.CubicBezier.C1, seg.Curve.CubicBezier.C2)
+		} else if seg.Curve.QuadraticBezier != nil {
+			ret = append(ret, seg.Curve.QuadraticBezier.C)
+		}
+	}
+	return ret
+}
+
+// MinimumBoundingRectangle returns the rectangle with the smallest area that contains all of the
+// points. One side of the rectangle is always parallel to an edge of the convex hull.
+func MinimumBoundingRectangle(points Points) *Rectangle {
+	h := hull(points)
+	if len(h) == 0 {
+		return nil
+	}
+	if len(h) < 3 {
+		a, b := h[0], h[len(h)-1]
+		d := b.SubtractPoint(a)
+		center := a.AddPoint(b).ScalarMult(0.5)
+		return NewRotatedRectangle(center.X, center.Y, d.Magnitude(), 0, math.Atan2(d.Y, d.X))
+	}
+
+	bestArea := math.Inf(1)
+	var best *Rectangle
+	for i := range h {
+		edge := h[(i+1)%len(h)].SubtractPoint(h[i])
+		angle := math.Atan2(edge.Y, edge.X)
+		minX, minY := math.Inf(1), math.Inf(1)
+		maxX, maxY := math.Inf(-1), math.Inf(-1)
+		for _, p := range h {
+			r := p.Rotate(-angle)
+			minX = math.Min(minX, r.X)
+			minY = math.Min(minY, r.Y)
+			maxX = math.Max(maxX, r.X)
+			maxY = math.Max(maxY, r.Y)
+		}
+		if area := (maxX - minX) * (maxY - minY); area < bestArea {
+			bestArea = area
+			center := point.NewPoint((minX+maxX)/2, (minY+maxY)/2).Rotate(angle)
+			best = NewRotatedRectangle(center.X, center.Y, maxX-minX, maxY-minY, angle)
+		}
+	}
+	return best
+}
+
+// MinimumEnclosingCircle returns the smallest circle that contains all of the points using
+// Welzl's algorithm. The points are shuffled with a fixed seed so the result is repeatable.
+func MinimumEnclosingCircle(points Points) circle.Circle {
+	pts := append([]point.Point{}, points...)
+	if len(pts) == 0 {
+		return circle.Circle{}
+	}
+	rng := rand.New(rand.NewSource(1))
+	rng.Shuffle(len(pts), func(i, j int) {
+		pts[i], pts[j] = pts[j], pts[i]
+	})
+
+	eps := 1e-9
+	contains := func(c circle.Circle, p point.Point) bool {
+		return c.Center.Distance(p) <= c.Radius*(1+eps)+eps
+	}
+	c := circle.Circle{Center: pts[0]}
+	for i := 1; i < len(pts); i++ {
+		if contains(c, pts[i]) {
+			continue
+		}
+		// pts[i] is on the boundary of the circle around the first i+1 points
+		c = circle.Circle{Center: pts[i]}
+		for j := 0; j < i; j++ {
+			if contains(c, pts[j]) {
+				continue
+			}
+			c = circle.Through(pts[i], pts[j])
+			for k := 0; k < j; k++ {
+				if contains(c, pts[k]) {
+					continue
+				}
+				if cc, ok := circle.Circumcircle(pts[i], pts[j], pts[k]); ok {
+					c = cc
+				}
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,53 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvexHull(t *testing.T) {
+	points := Points{{X: 0, Y: 0}, {X: 5, Y: 1}, {X: 10, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 10}, {X: 3, Y: 4}, {X: 0, Y: 10}}
+	h := ConvexHull(points)
+	assert.Equal(t, 4, len(h.Segments))
+	assert.True(t, h.IsClockwise())
+	assert.Equal(t, 100., h.Area())
+
+	curve := path.FromSegments([]path.Segment{
+		path.NewCubicBezierSegment(point.NewPoint(0, 0), point.NewPoint(0, -10), point.NewPoint(10, -10)),
+		path.NewSegment(10, 0),
+	}, false)
+	assert.Equal(t, 100., PathConvexHull(curve).Area())
+}
+
+func TestMinimumBoundingRectangle(t *testing.T) {
+	// A rotated rectangle with a point inside it
+	rect := NewRotatedRectangle(5, 5, 8, 2, math.Pi/6)
+	points := append(rect.Points(), point.NewPoint(5, 5))
+	min := MinimumBoundingRectangle(points)
+	assert.InDelta(t, 16, min.Area(), 1e-9)
+	assert.InDelta(t, 5, min.Center().X, 1e-9)
+	assert.InDelta(t, 5, min.Center().Y, 1e-9)
+	assert.InDelta(t, 0, math.Sin(min.Rotation()-math.Pi/6)*math.Cos(min.Rotation()-math.Pi/6), 1e-9)
+	for _, p := range points {
+		inside := p.AddPoint(min.Center().SubtractPoint(p).ScalarMult(1e-6))
+		assert.True(t, min.Contains(inside.X, inside.Y))
+	}
+	assert.False(t, min.Contains(1, 9))
+}
+
+func TestMinimumEnclosingCircle(t *testing.T) {
+	ngon := NewNgon(7, 3, 4, 10)
+	c := MinimumEnclosingCircle(append(ngon.Points(), point.NewPoint(3, 4), point.NewPoint(5, 5)))
+	assert.InDelta(t, 10, c.Radius, 1e-9)
+	assert.InDelta(t, 3, c.Center.X, 1e-9)
+	assert.InDelta(t, 4, c.Center.Y, 1e-9)
+
+	// Two points define the circle when the others are inside it
+	c = MinimumEnclosingCircle(Points{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 1}})
+	assert.Equal(t, point.NewPoint(5, 0), c.Center)
+	assert.Equal(t, 5., c.Radius)
+}
@@ -0,0 +1,172 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// SignedArea returns the area of the polygon. It is positive when the vertices turn clockwise on
+// screen, where y points down, and negative when they turn counter-clockwise.
+func (p *Polygon) SignedArea() float64 {
+	return signedArea(p.Points())
+}
+
+func (p *Polygon) Area() float64 {
+	return math.Abs(p.SignedArea())
+}
+
+func (p *Polygon) Perimeter() float64 {
+	return p.Length()
+}
+
+// Centroid returns the center of mass of the polygon. Polygons without any area return the
+// average of their vertices.
+func (p *Polygon) Centroid() point.Point {
+	ring := p.Points()
+	c, area := ringCentroid(ring)
+	if area != 0 || len(ring) == 0 {
+		return c
+	}
+	for _, pt := range ring {
+		c = c.AddPoint(pt)
+	}
+	return c.ScalarMult(1 / float64(len(ring)))
+}
+
+// IsClockwise returns true if the vertices turn clockwise on screen, where y points down
+func (p *Polygon) IsClockwise() bool {
+	return p.SignedArea() > 0
+}
+
+// Reverse returns the polygon with its vertices in the opposite order
+func (p *Polygon) Reverse() *Polygon {
+	return NewPolygon(Points(reverseRing(p.Points())))
+}
+
+// IsConvex returns true if the polygon turns the same way at every vertex and only goes around
+// once. Vertices where the polygon goes straight on are ignored.
+func (p *Polygon) IsConvex() bool {
+	ring := p.Points()
+	n := len(ring)
+	if n < 3 {
+		return false
+	}
+	sign := 0.
+	turning := 0.
+	for i := range ring {
+		a := ring[(i+n-1)%n]
+		b := ring[i]
+		c := ring[(i+1)%n]
+		ab := b.SubtractPoint(a)
+		bc := c.SubtractPoint(b)
+		cross := ab.X*bc.Y - ab.Y*bc.X
+		if cross != 0 {
+			if sign != 0 && math.Signbit(cross) != math.Signbit(sign) {
+				return false
+			}
+			sign = cross
+		}
+		turning += math.Atan2(cross, ab.Dot(bc))
+	}
+	return sign != 0 && math.Abs(turning) < 2*math.Pi+1e-6
+}
+
+// IsSimple returns true if none of the edges of the polygon cross or touch each other, other than
+// neighbouring edges meeting at their shared vertex.
+func (p *Polygon) IsSimple() bool {
+	ring := p.Points()
+	n := len(ring)
+	if n < 3 {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		a, b := ring[i], ring[(i+1)%n]
+		if a.Equals(b) {
+			return false
+		}
+		// Neighbouring edges can only overlap by doubling back on each other
+		c := ring[(i+2)%n]
+		ab, bc := b.SubtractPoint(a), c.SubtractPoint(b)
+		if ab.X*bc.Y-ab.Y*bc.X == 0 && ab.Dot(bc) < 0 {
+			return false
+		}
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			c, d := ring[j], ring[(j+1)%n]
+			if segmentsTouch(a, b, c, d) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// segmentsTouch returns true if the line segments ab and cd have any point in common
+func segmentsTouch(a, b, c, d point.Point) bool {
+	if _, _, ok := line.GetIntersectionParams(a.X, a.Y, b.X, b.Y, c.X, c.Y, d.X, d.Y); ok {
+		return true
+	}
+	ab := b.SubtractPoint(a)
+	ac := c.SubtractPoint(a)
+	if ab.X*ac.Y-ab.Y*ac.X != 0 {
+		return false
+	}
+	// The segments are parallel. They touch if they are on the same line and their projections
+	// overlap.
+	ad := d.SubtractPoint(a)
+	if ab.X*ad.Y-ab.Y*ad.X != 0 {
+		return false
+	}
+	l := ab.Dot(ab)
+	t0, t1 := ac.Dot(ab)/l, ad.Dot(ab)/l
+	return math.Max(t0, t1) >= 0 && math.Min(t0, t1) <= 1
+}
+
+// WindingNumber returns the number of times the polygon winds around x, y. Clockwise turns on
+// screen count as positive.
+func (p *Polygon) WindingNumber(x, y float64) int {
+	return winding(p.Points(), x, y)
+}
+
+// ContainsPointNonZero returns true if x, y is on the border of the polygon or the polygon winds
+// around it a non-zero number of times. Unlike ContainsPoint, areas of a self-intersecting
+// polygon that are covered twice are inside.
+func (p *Polygon) ContainsPointNonZero(x, y float64) bool {
+	return p.onBorder(x, y) || p.WindingNumber(x, y) != 0
+}
+
+// Distance returns the distance from x, y to the nearest edge of the polygon, following curved
+// edges exactly
+func (p *Polygon) Distance(x, y float64) float64 {
+	_, d := path.FromSegments(p.Segments, true).NearestPoint(point.NewPoint(x, y))
+	return d
+}
+
+// SignedDistance returns the distance from x, y to the nearest edge of the polygon, negated when
+// x, y is inside by the even-odd rule. It is the signed distance field of the polygon. Curved
+// edges are followed within DefaultTolerance when deciding which side of them x, y is on.
+func (p *Polygon) SignedDistance(x, y float64) float64 {
+	d := p.Distance(x, y)
+	if p.encloses(x, y) {
+		return -d
+	}
+	return d
+}
+
+// encloses returns true if x, y is inside the polygon by the even-odd rule, with curves
+// flattened to DefaultTolerance
+func (p *Polygon) encloses(x, y float64) bool {
+	return winding(p.Flatten(DefaultTolerance).Points(), x, y)%2 != 0
+}
+
+// onBorder returns true if x, y is within rounding error of an edge of the polygon
+func (p *Polygon) onBorder(x, y float64) bool {
+	b := p.GetBounds()
+	eps := 1e-9 * (1 + math.Max(b.Width(), b.Height()))
+	return ringDistance(p.Points(), x, y) <= eps
+}
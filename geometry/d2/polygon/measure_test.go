@@ -0,0 +1,110 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure(t *testing.T) {
+	rect := NewRectangle(0, 0, 4, 2)
+
+	t.Run("area and orientation", func(t *testing.T) {
+		assert.Equal(t, 8., rect.SignedArea())
+		assert.True(t, rect.IsClockwise())
+		reversed := rect.Reverse()
+		assert.Equal(t, -8., reversed.SignedArea())
+		assert.Equal(t, 8., reversed.Area())
+		assert.False(t, reversed.IsClockwise())
+		assert.Equal(t, 12., rect.Perimeter())
+	})
+
+	t.Run("centroid", func(t *testing.T) {
+		assert.Equal(t, point.NewPoint(2, 1), rect.Centroid())
+		l := NewPolygon(Points{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}})
+		c := l.Centroid()
+		assert.InDelta(t, 5./6, c.X, 1e-9)
+		assert.InDelta(t, 5./6, c.Y, 1e-9)
+		flat := NewPolygon(Points{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}})
+		assert.Equal(t, point.NewPoint(1, 0), flat.Centroid())
+	})
+
+	t.Run("convex", func(t *testing.T) {
+		assert.True(t, rect.IsConvex())
+		assert.True(t, rect.Reverse().IsConvex())
+		assert.True(t, NewNgon(7, 0, 0, 10).IsConvex())
+		assert.False(t, NewStar(0, 0, 10, 5, 5).IsConvex())
+		// A pentagram turns the same way at every vertex but goes around twice
+		pentagram := Points{}
+		for i := 0; i < 5; i++ {
+			pentagram = append(pentagram, point.NewPointFromAngle(float64(i)*4*math.Pi/5, 10))
+		}
+		assert.False(t, NewPolygon(pentagram).IsConvex())
+		assert.False(t, NewPolygon(pentagram).IsSimple())
+	})
+
+	t.Run("simple", func(t *testing.T) {
+		assert.True(t, rect.IsSimple())
+		assert.True(t, NewStar(0, 0, 10, 5, 5).IsSimple())
+		bowtie := NewPolygon(Points{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}})
+		assert.False(t, bowtie.IsSimple())
+		// Two squares touching at a vertex
+		touching := NewPolygon(Points{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 2}, {X: 1, Y: 1}, {X: 0, Y: 1}})
+		assert.False(t, touching.IsSimple())
+		spike := NewPolygon(Points{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}})
+		assert.False(t, spike.IsSimple())
+	})
+
+	t.Run("winding", func(t *testing.T) {
+		assert.Equal(t, 1, rect.WindingNumber(1, 1))
+		assert.Equal(t, -1, rect.Reverse().WindingNumber(1, 1))
+		assert.Equal(t, 0, rect.WindingNumber(5, 1))
+
+		// A ring that goes around the center twice
+		double := Points{}
+		for i := 0; i < 10; i++ {
+			double = append(double, point.NewPointFromAngle(float64(i)*4*math.Pi/10, 10))
+		}
+		p := NewPolygon(double)
+		assert.Equal(t, 2, p.WindingNumber(0, 0))
+		assert.False(t, p.ContainsPoint(0, 0))
+		assert.True(t, p.ContainsPointNonZero(0, 0))
+		assert.True(t, rect.ContainsPoint(4, 1.5))
+		assert.True(t, rect.ContainsPointNonZero(0, 0))
+	})
+
+	t.Run("distance", func(t *testing.T) {
+		assert.Equal(t, 1., rect.Distance(2, 1))
+		assert.Equal(t, 5., rect.Distance(7, 6))
+		assert.Equal(t, 0., rect.Distance(4, 1))
+
+		assert.Equal(t, -1., rect.SignedDistance(2, 1))
+		assert.Equal(t, 5., rect.SignedDistance(7, 6))
+		assert.Equal(t, 1., rect.SignedDistance(2, -1))
+
+		// Half of a circle of radius 50 centered on 50, 0, above the line between its ends
+		half := &Polygon{Path: path.FromSegments([]path.Segment{
+			{Point: point.NewPoint(0, 0), Curve: path.NewArc(50, 50, 0, false, true)},
+			path.NewSegment(100, 0),
+		}, true)}
+		assert.InDelta(t, -10, half.SignedDistance(50, -40), 1e-9)
+		assert.InDelta(t, 10, half.SignedDistance(50, -60), 1e-9)
+		assert.InDelta(t, 10, half.SignedDistance(50, 10), 1e-9)
+		assert.InDelta(t, -5, half.SignedDistance(50, -5), 1e-9)
+		assert.InDelta(t, math.Sqrt(50*50+20*20)-50, half.SignedDistance(70, -50), 1e-9)
+	})
+
+	t.Run("monotone", func(t *testing.T) {
+		assert.True(t, rect.IsXMonotone())
+		assert.True(t, rect.IsYMonotone())
+		// Vertical lines cross a U shape twice but horizontal lines can cross it four times
+		u := NewPolygon(Points{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 6}, {X: 6, Y: 6}, {X: 6, Y: 0}, {X: 9, Y: 0}, {X: 9, Y: 9}, {X: 0, Y: 9}})
+		assert.True(t, u.IsXMonotone())
+		assert.False(t, u.IsYMonotone())
+		assert.False(t, NewStar(0, 0, 10, 5, 5).IsMonotone(0.3))
+		assert.True(t, NewNgon(9, 0, 0, 10).IsMonotone(1))
+	})
+}
@@ -0,0 +1,314 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+
+	"github.com/engelsjk/polygol"
+)
+
+// A PolygonWithHoles is the area inside an outer ring with holes cut out of it by inner rings
+type PolygonWithHoles struct {
+	Outer *Polygon
+	Holes []*Polygon
+}
+
+func NewPolygonWithHoles(outer *Polygon, holes ...*Polygon) *PolygonWithHoles {
+	return &PolygonWithHoles{
+		Outer: outer,
+		Holes: holes,
+	}
+}
+
+// A MultiPolygon is a set of polygons with holes, such as the result of a boolean operation
+type MultiPolygon []*PolygonWithHoles
+
+// Rings returns the vertices of the outer ring followed by the holes
+func (p *PolygonWithHoles) Rings() [][]point.Point {
+	rings := make([][]point.Point, 0, len(p.Holes)+1)
+	rings = append(rings, p.Outer.Points())
+	for _, hole := range p.Holes {
+		rings = append(rings, hole.Points())
+	}
+	return rings
+}
+
+// Normalize returns a copy of the polygon with the outer ring turning clockwise on screen, so
+// its signed area is positive, and the holes turning the other way.
+func (p *PolygonWithHoles) Normalize() *PolygonWithHoles {
+	ret := &PolygonWithHoles{Outer: orient(p.Outer, true)}
+	for _, hole := range p.Holes {
+		ret.Holes = append(ret.Holes, orient(hole, false))
+	}
+	return ret
+}
+
+// orient returns the polygon with a positive signed area if positive is true and negative
+// otherwise
+func orient(p *Polygon, positive bool) *Polygon {
+	ring := p.Points()
+	if (signedArea(ring) < 0) == positive {
+		ring = reverseRing(ring)
+	}
+	return NewPolygon(Points(ring))
+}
+
+// ContainsPoint returns true if x, y is inside the polygon using the even-odd rule, so a point in
+// a hole is outside.
+func (p *PolygonWithHoles) ContainsPoint(x, y float64) bool {
+	wn := 0
+	for _, ring := range p.Rings() {
+		wn += winding(ring, x, y)
+	}
+	return wn%2 != 0
+}
+
+// ContainsPointNonZero returns true if the rings wind around x, y a non-zero number of times.
+// Holes only remove area when they turn the opposite way to the outer ring.
+func (p *PolygonWithHoles) ContainsPointNonZero(x, y float64) bool {
+	wn := 0
+	for _, ring := range p.Rings() {
+		wn += winding(ring, x, y)
+	}
+	return wn != 0
+}
+
+// Distance returns the distance from x, y to the nearest edge of the outer ring or a hole
+func (p *PolygonWithHoles) Distance(x, y float64) float64 {
+	ret := p.Outer.Distance(x, y)
+	for _, hole := range p.Holes {
+		ret = math.Min(ret, hole.Distance(x, y))
+	}
+	return ret
+}
+
+// SignedDistance returns the distance from x, y to the nearest edge, negated when x, y is
+// inside the polygon and not in a hole
+func (p *PolygonWithHoles) SignedDistance(x, y float64) float64 {
+	d := p.Distance(x, y)
+	if p.encloses(x, y) {
+		return -d
+	}
+	return d
+}
+
+// encloses returns true if x, y is inside the outer ring and not in a hole, with curves
+// flattened to DefaultTolerance
+func (p *PolygonWithHoles) encloses(x, y float64) bool {
+	if !p.Outer.encloses(x, y) {
+		return false
+	}
+	for _, hole := range p.Holes {
+		if hole.encloses(x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// Area returns the area of the outer ring minus the area of the holes
+func (p *PolygonWithHoles) Area() float64 {
+	area := math.Abs(signedArea(p.Outer.Points()))
+	for _, hole := range p.Holes {
+		area -= math.Abs(signedArea(hole.Points()))
+	}
+	return area
+}
+
+// Centroid returns the center of mass of the polygon with the holes removed
+func (p *PolygonWithHoles) Centroid() point.Point {
+	c, area := ringCentroid(p.Outer.Points())
+	area = math.Abs(area)
+	sum := c.ScalarMult(area)
+	total := area
+	for _, hole := range p.Holes {
+		hc, harea := ringCentroid(hole.Points())
+		harea = math.Abs(harea)
+		sum = sum.SubtractPoint(hc.ScalarMult(harea))
+		total -= harea
+	}
+	if total == 0 {
+		return c
+	}
+	return sum.ScalarMult(1 / total)
+}
+
+// Rings returns the rings of all of the polygons
+func (m MultiPolygon) Rings() [][]point.Point {
+	var rings [][]point.Point
+	for _, p := range m {
+		rings = append(rings, p.Rings()...)
+	}
+	return rings
+}
+
+// Normalize returns a copy with each polygon normalized
+func (m MultiPolygon) Normalize() MultiPolygon {
+	ret := make(MultiPolygon, len(m))
+	for i, p := range m {
+		ret[i] = p.Normalize()
+	}
+	return ret
+}
+
+func (m MultiPolygon) ContainsPoint(x, y float64) bool {
+	for _, p := range m {
+		if p.ContainsPoint(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m MultiPolygon) ContainsPointNonZero(x, y float64) bool {
+	for _, p := range m {
+		if p.ContainsPointNonZero(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// Distance returns the distance from x, y to the nearest edge of any of the polygons
+func (m MultiPolygon) Distance(x, y float64) float64 {
+	ret := math.Inf(1)
+	for _, p := range m {
+		ret = math.Min(ret, p.Distance(x, y))
+	}
+	return ret
+}
+
+// SignedDistance returns the distance from x, y to the nearest edge of any of the polygons,
+// negated when x, y is inside one of them
+func (m MultiPolygon) SignedDistance(x, y float64) float64 {
+	d := m.Distance(x, y)
+	for _, p := range m {
+		if p.encloses(x, y) {
+			return -d
+		}
+	}
+	return d
+}
+
+func (m MultiPolygon) Area() float64 {
+	area := 0.
+	for _, p := range m {
+		area += p.Area()
+	}
+	return area
+}
+
+// Centroid returns the center of mass of all of the polygons
+func (m MultiPolygon) Centroid() point.Point {
+	var sum point.Point
+	total := 0.
+	for _, p := range m {
+		area := p.Area()
+		sum = sum.AddPoint(p.Centroid().ScalarMult(area))
+		total += area
+	}
+	if total == 0 {
+		return sum
+	}
+	return sum.ScalarMult(1 / total)
+}
+
+func (m MultiPolygon) Union(others ...MultiPolygon) (MultiPolygon, error) {
+	return apply(polygol.Union, m.ToGeom(), multiGeoms(others))
+}
+
+func (m MultiPolygon) Intersection(others ...MultiPolygon) (MultiPolygon, error) {
+	return apply(polygol.Intersection, m.ToGeom(), multiGeoms(others))
+}
+
+func (m MultiPolygon) Difference(others ...MultiPolygon) (MultiPolygon, error) {
+	return apply(polygol.Difference, m.ToGeom(), multiGeoms(others))
+}
+
+func (m MultiPolygon) XOR(others ...MultiPolygon) (MultiPolygon, error) {
+	return apply(polygol.XOR, m.ToGeom(), multiGeoms(others))
+}
+
+func multiGeoms(multipolygons []MultiPolygon) []polygol.Geom {
+	geoms := make([]polygol.Geom, len(multipolygons))
+	for i, m := range multipolygons {
+		geoms[i] = m.ToGeom()
+	}
+	return geoms
+}
+
+func (p *PolygonWithHoles) ToGeom() polygol.Geom {
+	rings := [][][]float64{toRing(p.Outer)}
+	for _, hole := range p.Holes {
+		rings = append(rings, toRing(hole))
+	}
+	return polygol.Geom{rings}
+}
+
+func (m MultiPolygon) ToGeom() polygol.Geom {
+	geom := polygol.Geom{}
+	for _, p := range m {
+		geom = append(geom, p.ToGeom()...)
+	}
+	return geom
+}
+
+// Polygons returns the outer ring of each polygon in the set
+func (m MultiPolygon) Polygons() []*Polygon {
+	ret := make([]*Polygon, len(m))
+	for i, p := range m {
+		ret[i] = p.Outer
+	}
+	return ret
+}
+
+func (p *Polygon) ToGeom() polygol.Geom {
+	return polygol.Geom{{toRing(p)}}
+}
+
+func ToGeoms(polygons ...*Polygon) []polygol.Geom {
+	geoms := make([]polygol.Geom, len(polygons))
+	for i, polygon := range polygons {
+		geoms[i] = polygon.ToGeom()
+	}
+	return geoms
+}
+
+// FromGeom converts a polygol multipolygon into polygons with holes. The first ring of each
+// polygon is its outer ring and the rest are holes.
+func FromGeom(multipolygon polygol.Geom) MultiPolygon {
+	ret := make(MultiPolygon, 0, len(multipolygon))
+	for _, rings := range multipolygon {
+		if len(rings) == 0 {
+			continue
+		}
+		p := &PolygonWithHoles{Outer: fromRing(rings[0])}
+		for _, ring := range rings[1:] {
+			p.Holes = append(p.Holes, fromRing(ring))
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+// toRing returns the vertices of the polygon. Curves are flattened.
+func toRing(p *Polygon) [][]float64 {
+	var ring [][]float64
+	for _, pt := range p.Flatten(DefaultTolerance).Points() {
+		ring = append(ring, []float64{pt.X, pt.Y})
+	}
+	return ring
+}
+
+// fromRing creates a polygon from a polygol ring. The repeated closing vertex is dropped.
+func fromRing(ring [][]float64) *Polygon {
+	var points Points
+	for _, vertex := range ring {
+		points = append(points, point.Point{X: vertex[0], Y: vertex[1]})
+	}
+	if len(points) > 1 && points[0].Equals(points[len(points)-1]) {
+		points = points[:len(points)-1]
+	}
+	return NewPolygon(points)
+}
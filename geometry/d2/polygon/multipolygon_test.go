@@ -0,0 +1,85 @@
+package polygon
+
+import (
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolygonWithHoles(t *testing.T) {
+	outer := NewRectangle(0, 0, 10, 10).Polygon
+	hole := NewRectangle(2, 2, 4, 4).Polygon
+	donut := NewPolygonWithHoles(outer, hole)
+
+	t.Run("normalize", func(t *testing.T) {
+		backwards := NewPolygonWithHoles(NewPolygon(Points(reverseRing(outer.Points()))), hole)
+		normalized := backwards.Normalize()
+		assert.True(t, signedArea(normalized.Outer.Points()) > 0)
+		assert.True(t, signedArea(normalized.Holes[0].Points()) < 0)
+		assert.Equal(t, outer.Points(), normalized.Outer.Points())
+	})
+
+	t.Run("contains point", func(t *testing.T) {
+		assert.True(t, donut.ContainsPoint(1, 1))
+		assert.False(t, donut.ContainsPoint(4, 4))
+		assert.False(t, donut.ContainsPoint(11, 4))
+
+		// Holes that turn the same way as the outer ring do not remove area with nonzero
+		assert.True(t, donut.ContainsPointNonZero(4, 4))
+		assert.False(t, donut.Normalize().ContainsPointNonZero(4, 4))
+		assert.True(t, donut.Normalize().ContainsPointNonZero(1, 1))
+	})
+
+	t.Run("distance", func(t *testing.T) {
+		assert.Equal(t, -1., donut.SignedDistance(1, 5))
+		// In the hole the nearest edge is the hole's
+		assert.Equal(t, 1., donut.SignedDistance(3, 4))
+		assert.Equal(t, 2., donut.SignedDistance(12, 5))
+		assert.Equal(t, 0., donut.Distance(2, 3))
+
+		m := MultiPolygon{donut, NewPolygonWithHoles(NewRectangle(20, 0, 10, 10).Polygon)}
+		assert.Equal(t, 5., m.SignedDistance(15, 5))
+		assert.Equal(t, -3., m.SignedDistance(23, 5))
+		assert.Equal(t, 1., m.SignedDistance(4, 3))
+
+		// A round hole is measured along its curve
+		circle := NewPolygonWithHoles(NewRectangle(0, 0, 100, 100).Polygon, &Polygon{Path: path.FromSegments([]path.Segment{
+			{Point: point.NewPoint(20, 50), Curve: path.NewArc(30, 30, 0, false, true)},
+			{Point: point.NewPoint(80, 50), Curve: path.NewArc(30, 30, 0, false, true)},
+		}, true)})
+		assert.InDelta(t, 10, circle.SignedDistance(50, 30), 1e-9)
+		assert.InDelta(t, -5, circle.SignedDistance(50, 15), 1e-9)
+		assert.InDelta(t, -5, MultiPolygon{circle}.SignedDistance(50, 85), 1e-9)
+		assert.InDelta(t, 10, MultiPolygon{circle}.SignedDistance(50, 30), 1e-9)
+	})
+
+	t.Run("area and centroid", func(t *testing.T) {
+		assert.Equal(t, 84., donut.Area())
+		c := donut.Centroid()
+		assert.InDelta(t, (5*100-4*16)/84., c.X, 1e-9)
+		assert.InDelta(t, (5*100-4*16)/84., c.Y, 1e-9)
+
+		m := MultiPolygon{NewPolygonWithHoles(outer), NewPolygonWithHoles(NewRectangle(20, 0, 10, 10).Polygon)}
+		assert.Equal(t, 200., m.Area())
+		assert.Equal(t, point.NewPoint(15, 5), m.Centroid())
+	})
+
+	t.Run("boolean operations", func(t *testing.T) {
+		m := MultiPolygon{donut}
+		result, err := m.Union(MultiPolygon{NewPolygonWithHoles(NewRectangle(3, 3, 2, 2).Polygon)})
+		assert.Nil(t, err)
+		// The square is an island in the hole
+		assert.Equal(t, 2, len(result))
+		assert.Equal(t, 88., result.Area())
+		assert.True(t, result.ContainsPoint(4, 4))
+		assert.False(t, result.ContainsPoint(5.5, 5.5))
+
+		result, err = m.Difference(MultiPolygon{NewPolygonWithHoles(NewRectangle(5, -1, 10, 12).Polygon)})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, 0, len(result[0].Holes))
+		assert.Equal(t, 38., result.Area())
+	})
+}
@@ -7,8 +7,6 @@ import (
 	"github.com/srmullen/godraw-lib/geometry/d2/line"
 	"github.com/srmullen/godraw-lib/geometry/d2/path"
 	"github.com/srmullen/godraw-lib/geometry/d2/point"
-
-	"github.com/engelsjk/polygol"
 )
 
 type Points []point.Point
@@ -50,60 +48,44 @@ func NewStar(x, y, radius, innerRadius float64, points int) *Polygon {
 	}
 }
 
-func isLess(xs []float64, i, j int) bool {
-	// return segment < next && segment < prev
-	return ((xs[i%len(xs)] < xs[j%len(xs)]) || (xs[i%len(xs)] == xs[j%len(xs)] && i < j))
-}
-
-// l is some other line of the form ax + by = c
-// FIXME: need to be able to pass in the line
-func isLessGen(xs, ys []float64, i, j int) bool {
-	// How to pass in the line varaibles?
-	a := 1.0
-	b := 1.0
-	// c := 1.0
-
-	di := a*xs[i] + b*ys[i]
-	dj := a*xs[j%len(xs)] + b*ys[j%len(ys)]
-	return ((di < dj) || (di == dj && i < j))
-}
-
-// FIXME: doesn't work. need to fix isLessGen
-func (p *Polygon) IsMonotone() bool {
-	xs := p.Xs()
+// IsMonotone returns true if every line perpendicular to the direction at angle (radians)
+// crosses the polygon at most twice.
+// https://cs.stackexchange.com/questions/1577/how-do-i-test-if-a-polygon-is-monotone-with-respect-to-a-line
+func (p *Polygon) IsMonotone(angle float64) bool {
+	dx, dy := math.Cos(angle), math.Sin(angle)
+	var ds []float64
+	for _, pt := range p.Points() {
+		d := pt.X*dx + pt.Y*dy
+		// Runs of equal values are treated as a single vertex
+		if len(ds) == 0 || ds[len(ds)-1] != d {
+			ds = append(ds, d)
+		}
+	}
+	for len(ds) > 1 && ds[0] == ds[len(ds)-1] {
+		ds = ds[:len(ds)-1]
+	}
+	if len(ds) < 3 {
+		return true
+	}
+	// A monotone polygon goes up to its maximum and back down once, so it has one local minimum
 	localMins := 0
-	for i := 1; i < len(xs); i++ {
-		if isLess(xs, i, i-1) && isLess(xs, i, i+1) {
+	n := len(ds)
+	for i := range ds {
+		if ds[i] < ds[(i+n-1)%n] && ds[i] < ds[(i+1)%n] {
 			localMins += 1
 		}
 	}
 	return localMins == 1
 }
 
-// https://cs.stackexchange.com/questions/1577/how-do-i-test-if-a-polygon-is-monotone-with-respect-to-a-line
 // Monotone with resect to the x-axis
 func (p *Polygon) IsXMonotone() bool {
-	xs := p.Xs()
-	localMins := 0
-	for i := 1; i < len(xs); i++ {
-		if isLess(xs, i, i-1) && isLess(xs, i, i+1) {
-			localMins += 1
-		}
-	}
-	return localMins == 1
+	return p.IsMonotone(0)
 }
 
 // Monotone with resect to the y-axis
-// Not working - isLess doesn't work for this
 func (p *Polygon) IsYMonotone() bool {
-	ys := p.Ys()
-	localMins := 0
-	for i := 1; i < len(ys); i++ {
-		if isLess(ys, i, i-1) && isLess(ys, i, i+1) {
-			localMins += 1
-		}
-	}
-	return localMins == 1
+	return p.IsMonotone(math.Pi / 2)
 }
 
 func NewNgon(sides int, x, y, radius float64) *Polygon {
@@ -141,36 +123,45 @@ func (p *Polygon) GetIntersections(other *Polygon) Points {
 	return intersections
 }
 
+// LineIntersections returns the points where the line segment crosses the boundary of the polygon.
+// A line that passes through a vertex is counted once if it crosses the boundary there and zero
+// or two times if it only touches the vertex, so the number of intersections to either side of a
+// point on the line can be used to decide if it is inside the polygon.
 func (p *Polygon) LineIntersections(lne *path.Path) Points {
-	// var intersections []*point.Point
+	start := lne.Segments[0].Point
+	end := lne.Segments[1].Point
+	length := end.Distance(start)
 	intersections := Points{}
+	if length == 0 {
+		return intersections
+	}
+	dir := end.SubtractPoint(start).ScalarMult(1 / length)
+	side := func(pnt point.Point) float64 {
+		v := pnt.SubtractPoint(start)
+		cross := dir.X*v.Y - dir.Y*v.X
+		if math.Abs(cross) < 1e-9 {
+			return 0
+		}
+		return cross
+	}
 	for i := 0; i < len(p.Segments); i++ {
 		from := p.Segments[i].Point
 		to := p.Segments[(i+1)%len(p.Segments)].Point
-		if x, y, ok := line.GetIntersection(from.X, from.Y, to.X, to.Y, lne.Segments[0].X, lne.Segments[0].Y, lne.Segments[1].X, lne.Segments[1].Y); ok {
-			intersections = append(intersections, point.NewPoint(x, y))
+		sf := side(from)
+		st := side(to)
+		// An edge that ends on the line is only counted if its other end is on the positive side
+		if (sf <= 0 && st <= 0) || (sf > 0 && st > 0) {
+			continue
 		}
-		// intersection := line.NewLine(from.X, from.Y, to.X, to.Y).Intersection(lne)
-		// if intersection != nil {
-		// 	intersections = append(intersections, intersection)
-		// }
-	}
-	// Remove duplicates (intersection through the corner of a polygon)
-	// ret := []*point.Point{}
-	ret := Points{}
-	for _, intersection := range intersections {
-		found := false
-		for _, point := range ret {
-			if point.EqualsWithTolerance(intersection, 0.0001) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			ret = append(ret, intersection)
+		t := sf / (sf - st)
+		intersection := from.AddPoint(to.SubtractPoint(from).ScalarMult(t))
+		u := intersection.SubtractPoint(start).Dot(dir)
+		if u < -1e-9 || u > length+1e-9 {
+			continue
 		}
+		intersections = append(intersections, intersection)
 	}
-	return ret
+	return intersections
 }
 
 func (p *Polygon) Translate(x, y float64) *Polygon {
@@ -185,79 +176,16 @@ func (p *Polygon) Scale(scalex, scaley float64) *Polygon {
 	}
 }
 
-func (p *Polygon) ToGeom() polygol.Geom {
-	var points [][]float64
-	for _, segment := range p.Segments {
-		points = append(points, []float64{segment.X, segment.Y})
-	}
-	return [][][][]float64{{points}}
-}
-
-func ToGeoms(polygons ...*Polygon) []polygol.Geom {
-	geoms := make([]polygol.Geom, len(polygons))
-	for i, polygon := range polygons {
-		geoms[i] = polygon.ToGeom()
-	}
-	return geoms
-}
-
-func FromGeom(multipolygon polygol.Geom) []*Polygon {
-	polygons := make([]*Polygon, len(multipolygon))
-	for i, polygon := range multipolygon {
-		var points Points
-		for _, vertex := range polygon[0] {
-			points = append(points, point.Point{X: vertex[0], Y: vertex[1]})
-		}
-		polygons[i] = NewPolygon(points)
-	}
-	return polygons
-}
-
-func (p *Polygon) Union(others ...*Polygon) []*Polygon {
-	geoms := ToGeoms(others...)
-	multipolygons, _ := polygol.Union(p.ToGeom(), geoms...)
-	return FromGeom(multipolygons)
-}
-
-func (p *Polygon) Intersection(others ...*Polygon) []*Polygon {
-	geoms := ToGeoms(others...)
-	multipolygons, _ := polygol.Intersection(p.ToGeom(), geoms...)
-	return FromGeom(multipolygons)
-}
-
-func (p *Polygon) Difference(others ...*Polygon) []*Polygon {
-	geoms := ToGeoms(others...)
-	multipolygons, _ := polygol.Difference(p.ToGeom(), geoms...)
-	return FromGeom(multipolygons)
-}
-
-func (p *Polygon) XOR(others ...*Polygon) []*Polygon {
-	geoms := ToGeoms(others...)
-	multipolygons, _ := polygol.XOR(p.ToGeom(), geoms...)
-	return FromGeom(multipolygons)
-}
-
+// ContainsPoint returns true if x, y is inside the polygon by the even-odd rule or on its border
 func (poly *Polygon) ContainsPoint(x, y float64) bool {
 	bound := poly.GetBounds()
 	if x < bound.Left || x > bound.Right || y < bound.Top || y > bound.Bottom {
 		return false
 	}
-	// create a horizontal line that extends from the point
-	lne := path.NewLine(x, y, bound.Right+21, y)
-
-	// count the number of intersections with the polygon
-	intersections := poly.LineIntersections(&lne.Path)
-
-	// if the point is on the border, then the polygon contains the point
-	for _, intersection := range intersections {
-		// log.Println(intersection.X, intersection.Y, x, y)
-		if intersection.X == x && intersection.Y == y {
-			return true
-		}
+	if poly.onBorder(x, y) {
+		return true
 	}
-
-	// if the number of intersections is odd, then the point is inside the polygon
-	return len(intersections)%2 == 1
+	return poly.WindingNumber(x, y)%2 != 0
 }
 
 func GetTriangleCenter(p1, p2, p3 *point.Point) point.Point {
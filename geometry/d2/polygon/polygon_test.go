@@ -3,6 +3,8 @@ package polygon
 import (
 	"testing"
 
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -98,3 +100,23 @@ func TestGetIntersections(t *testing.T) {
 		assert.True(t, star.ContainsPoint(51, 100))   // on border
 	})
 }
+
+func TestLineIntersections(t *testing.T) {
+	diamond := NewPolygon(Points{{X: 5, Y: 0}, {X: 10, Y: 5}, {X: 5, Y: 10}, {X: 0, Y: 5}})
+
+	t.Run("line crossing through vertices", func(t *testing.T) {
+		lne := path.NewLine(-1, 5, 11, 5)
+		intersections := diamond.LineIntersections(&lne.Path)
+		assert.Equal(t, Points{{X: 10, Y: 5}, {X: 0, Y: 5}}, intersections)
+	})
+
+	t.Run("line touching a vertex", func(t *testing.T) {
+		lne := path.NewLine(-1, 0, 11, 0)
+		intersections := diamond.LineIntersections(&lne.Path)
+		assert.Equal(t, 0, len(intersections)%2)
+
+		lne = path.NewLine(-1, 10, 11, 10)
+		intersections = diamond.LineIntersections(&lne.Path)
+		assert.Equal(t, 0, len(intersections)%2)
+	})
+}
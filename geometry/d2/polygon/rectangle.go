@@ -11,6 +11,8 @@ type Rectangle struct {
 	y      float64
 	width  float64
 	height float64
+	// rotation in radians around the center
+	rotation float64
 }
 
 func NewRectangle(x, y, width, height float64) *Rectangle {
@@ -24,11 +26,11 @@ func NewRectangle(x, y, width, height float64) *Rectangle {
 		Path: path.NewPath(pd, true),
 	}
 	return &Rectangle{
-		poly,
-		x,
-		y,
-		width,
-		height,
+		Polygon: poly,
+		x:       x,
+		y:       y,
+		width:   width,
+		height:  height,
 	}
 }
 
@@ -39,10 +41,35 @@ func NewRectangleFromCenter(centerx, centery, width, height float64) *Rectangle
 	return NewRectangle(x, y, width, height)
 }
 
+// NewRotatedRectangle creates a rectangle with the center at x, y that is rotated by rotation
+// radians around its center
+func NewRotatedRectangle(centerx, centery, width, height, rotation float64) *Rectangle {
+	center := point.NewPoint(centerx, centery)
+	corners := Points{
+		point.NewPoint(-width/2, -height/2),
+		point.NewPoint(width/2, -height/2),
+		point.NewPoint(width/2, height/2),
+		point.NewPoint(-width/2, height/2),
+	}
+	for i, c := range corners {
+		corners[i] = c.Rotate(rotation).AddPoint(center)
+	}
+	return &Rectangle{
+		Polygon:  NewPolygon(corners),
+		x:        centerx - width/2,
+		y:        centery - height/2,
+		width:    width,
+		height:   height,
+		rotation: rotation,
+	}
+}
+
+// X returns the left of the rectangle before it is rotated
 func (r *Rectangle) X() float64 {
 	return r.x
 }
 
+// Y returns the top of the rectangle before it is rotated
 func (r *Rectangle) Y() float64 {
 	return r.y
 }
@@ -55,10 +82,19 @@ func (r *Rectangle) Height() float64 {
 	return r.height
 }
 
+// Rotation returns the angle in radians that the rectangle is rotated around its center
+func (r *Rectangle) Rotation() float64 {
+	return r.rotation
+}
+
 func (r *Rectangle) Center() point.Point {
 	return point.NewPoint(r.x+r.width/2, r.y+r.height/2)
 }
 
 func (r *Rectangle) Contains(x, y float64) bool {
+	if r.rotation != 0 {
+		p := point.NewPoint(x, y).SubtractPoint(r.Center()).Rotate(-r.rotation).AddPoint(r.Center())
+		x, y = p.X, p.Y
+	}
 	return r.x <= x && x <= r.x+r.width && r.y <= y && y <= r.y+r.height
 }
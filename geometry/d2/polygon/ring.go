@@ -0,0 +1,77 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/line"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// signedArea returns the shoelace area of the ring. It is positive when the ring turns clockwise
+// on screen, where y points down.
+func signedArea(ring []point.Point) float64 {
+	area := 0.
+	for i := range ring {
+		a := ring[i]
+		b := ring[(i+1)%len(ring)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area / 2
+}
+
+// ringCentroid returns the centroid of the area enclosed by the ring and its signed area
+func ringCentroid(ring []point.Point) (point.Point, float64) {
+	var cx, cy, area float64
+	for i := range ring {
+		a := ring[i]
+		b := ring[(i+1)%len(ring)]
+		cross := a.X*b.Y - b.X*a.Y
+		area += cross
+		cx += (a.X + b.X) * cross
+		cy += (a.Y + b.Y) * cross
+	}
+	area /= 2
+	if area == 0 {
+		return point.Point{}, 0
+	}
+	return point.NewPoint(cx/(6*area), cy/(6*area)), area
+}
+
+// winding returns the number of times the ring winds around x, y. Turns that are clockwise on
+// screen are positive.
+func winding(ring []point.Point, x, y float64) int {
+	wn := 0
+	for i := range ring {
+		a := ring[i]
+		b := ring[(i+1)%len(ring)]
+		side := (b.X-a.X)*(y-a.Y) - (x-a.X)*(b.Y-a.Y)
+		if a.Y <= y {
+			if b.Y > y && side > 0 {
+				wn++
+			}
+		} else if b.Y <= y && side < 0 {
+			wn--
+		}
+	}
+	return wn
+}
+
+func reverseRing(ring []point.Point) []point.Point {
+	ret := make([]point.Point, len(ring))
+	for i, p := range ring {
+		ret[len(ring)-1-i] = p
+	}
+	return ret
+}
+
+// ringDistance returns the distance from x, y to the nearest edge of the ring, taking each edge
+// as the straight line between its vertices
+func ringDistance(ring []point.Point, x, y float64) float64 {
+	ret := math.Inf(1)
+	for i := range ring {
+		a := ring[i]
+		b := ring[(i+1)%len(ring)]
+		ret = math.Min(ret, line.DistanceToPoint(a.X, a.Y, b.X, b.Y, x, y))
+	}
+	return ret
+}
@@ -0,0 +1,311 @@
+package polygon
+
+import (
+	"math"
+	"sort"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+// Triangulate splits the polygon into triangles by ear clipping. The triangles turn clockwise on
+// screen. Self-intersecting polygons are not supported.
+func (p *Polygon) Triangulate() []*Polygon {
+	return NewPolygonWithHoles(p).Triangulate()
+}
+
+// Triangulate splits the polygon into triangles that cover it and leave the holes empty.
+// Each hole is joined to the outer ring by a pair of edges so the result can be ear clipped.
+func (p *PolygonWithHoles) Triangulate() []*Polygon {
+	ring := clean(p.Outer.Points())
+	if signedArea(ring) < 0 {
+		ring = reverseRing(ring)
+	}
+	holes := make([][]point.Point, 0, len(p.Holes))
+	for _, hole := range p.Holes {
+		h := clean(hole.Points())
+		if len(h) < 3 {
+			continue
+		}
+		if signedArea(h) > 0 {
+			h = reverseRing(h)
+		}
+		holes = append(holes, h)
+	}
+	// Holes are bridged from right to left so each bridge can only cross holes that have
+	// already been merged into the ring
+	sort.Slice(holes, func(i, j int) bool {
+		return holes[i][rightmost(holes[i])].X > holes[j][rightmost(holes[j])].X
+	})
+	for _, hole := range holes {
+		ring = bridge(ring, hole)
+	}
+
+	var ret []*Polygon
+	for _, tri := range earClip(ring) {
+		ret = append(ret, NewPolygon(Points(tri[:])))
+	}
+	return ret
+}
+
+// ConvexDecomposition splits the polygon into convex polygons. Triangles are merged across the
+// diagonals between them whenever the result is still convex, which gives at most four times
+// the smallest possible number of pieces.
+func (p *Polygon) ConvexDecomposition() []*Polygon {
+	return NewPolygonWithHoles(p).ConvexDecomposition()
+}
+
+// ConvexDecomposition splits the polygon into convex polygons that leave the holes empty
+func (p *PolygonWithHoles) ConvexDecomposition() []*Polygon {
+	var pieces [][]point.Point
+	for _, tri := range p.Triangulate() {
+		pieces = append(pieces, tri.Points())
+	}
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(pieces) && !merged; i++ {
+			for j := i + 1; j < len(pieces) && !merged; j++ {
+				if m := mergeConvex(pieces[i], pieces[j]); m != nil {
+					pieces[i] = m
+					pieces = append(pieces[:j], pieces[j+1:]...)
+					merged = true
+				}
+			}
+		}
+	}
+	ret := make([]*Polygon, len(pieces))
+	for i, piece := range pieces {
+		ret[i] = NewPolygon(Points(piece))
+	}
+	return ret
+}
+
+// mergeConvex joins the rings if they share an edge and the result is convex. It returns nil
+// otherwise. Both rings must turn clockwise on screen.
+func mergeConvex(a, b []point.Point) []point.Point {
+	for i := range a {
+		a0, a1 := a[i], a[(i+1)%len(a)]
+		for j := range b {
+			if !b[j].Equals(a1) || !b[(j+1)%len(b)].Equals(a0) {
+				continue
+			}
+			merged := make([]point.Point, 0, len(a)+len(b)-2)
+			for k := 1; k <= len(a); k++ {
+				merged = append(merged, a[(i+k)%len(a)])
+			}
+			for k := 2; k < len(b); k++ {
+				merged = append(merged, b[(j+k)%len(b)])
+			}
+			merged = clean(merged)
+			for k := range merged {
+				if cross(merged[(k+len(merged)-1)%len(merged)], merged[k], merged[(k+1)%len(merged)]) < 0 {
+					return nil
+				}
+			}
+			return merged
+		}
+	}
+	return nil
+}
+
+// cross returns the cross product of the edges ab and bc. It is positive where a ring that turns
+// clockwise on screen has a convex corner at b.
+func cross(a, b, c point.Point) float64 {
+	return (b.X-a.X)*(c.Y-b.Y) - (b.Y-a.Y)*(c.X-b.X)
+}
+
+// clean removes repeated vertices and vertices where the ring goes straight on
+func clean(ring []point.Point) []point.Point {
+	ret := make([]point.Point, 0, len(ring))
+	for _, p := range ring {
+		if len(ret) == 0 || !ret[len(ret)-1].Equals(p) {
+			ret = append(ret, p)
+		}
+	}
+	for len(ret) > 1 && ret[0].Equals(ret[len(ret)-1]) {
+		ret = ret[:len(ret)-1]
+	}
+	for changed := true; changed && len(ret) >= 3; {
+		changed = false
+		for i := range ret {
+			a := ret[(i+len(ret)-1)%len(ret)]
+			c := ret[(i+1)%len(ret)]
+			ab := ret[i].SubtractPoint(a)
+			bc := c.SubtractPoint(ret[i])
+			if cross(a, ret[i], c) == 0 && ab.Dot(bc) > 0 {
+				ret = append(ret[:i], ret[i+1:]...)
+				changed = true
+				break
+			}
+		}
+	}
+	return ret
+}
+
+func rightmost(ring []point.Point) int {
+	ret := 0
+	for i, p := range ring {
+		if p.X > ring[ret].X {
+			ret = i
+		}
+	}
+	return ret
+}
+
+// bridge joins the hole to the ring with two edges from the hole's rightmost vertex to a vertex of
+// the ring that can see it. The ring must turn clockwise on screen and the hole the other way.
+// https://www.geometrictools.com/Documentation/TriangulationByEarClipping.pdf
+func bridge(ring, hole []point.Point) []point.Point {
+	mi := rightmost(hole)
+	m := hole[mi]
+
+	// Find the closest edge to the right of m along the horizontal ray from it
+	best := -1
+	bestX := math.Inf(1)
+	n := len(ring)
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%n]
+		if a.Y == b.Y || m.Y < math.Min(a.Y, b.Y) || m.Y > math.Max(a.Y, b.Y) {
+			continue
+		}
+		x := a.X + (m.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+		if x >= m.X && x < bestX {
+			best = i
+			bestX = x
+		}
+	}
+	if best < 0 {
+		return ring
+	}
+	a, b := ring[best], ring[(best+1)%n]
+	var vi int
+	switch {
+	case a.Y == m.Y && a.X == bestX:
+		vi = best
+	case b.Y == m.Y && b.X == bestX:
+		vi = (best + 1) % n
+	default:
+		// The end of the edge furthest right is visible unless a reflex vertex of the ring is
+		// inside the triangle between m, the intersection and that end. In that case the reflex
+		// vertex closest in angle to the ray is visible.
+		vi = best
+		if b.X > a.X {
+			vi = (best + 1) % n
+		}
+		intersection := point.NewPoint(bestX, m.Y)
+		p := ring[vi]
+		bestAngle := math.Inf(1)
+		for i := range ring {
+			if i == vi || !inTriangle(ring[i], m, intersection, p) {
+				continue
+			}
+			if cross(ring[(i+n-1)%n], ring[i], ring[(i+1)%n]) >= 0 {
+				continue
+			}
+			d := ring[i].SubtractPoint(m)
+			angle := math.Abs(math.Atan2(d.Y, d.X))
+			if angle < bestAngle || (angle == bestAngle && d.Magnitude() < ring[vi].Distance(m)) {
+				bestAngle = angle
+				vi = i
+			}
+		}
+	}
+
+	// Earlier bridges repeat vertices. Use the copy whose corner the bridge leaves from.
+	for i := range ring {
+		if ring[i].Equals(ring[vi]) && inCorner(ring[(i+n-1)%n], ring[i], ring[(i+1)%n], m) {
+			vi = i
+			break
+		}
+	}
+
+	ret := make([]point.Point, 0, n+len(hole)+2)
+	ret = append(ret, ring[:vi+1]...)
+	for k := 0; k <= len(hole); k++ {
+		ret = append(ret, hole[(mi+k)%len(hole)])
+	}
+	ret = append(ret, ring[vi])
+	return append(ret, ring[vi+1:]...)
+}
+
+// inCorner returns true if the direction from b to p is inside the corner of a ring that turns
+// clockwise on screen where it goes from a to b to c.
+func inCorner(a, b, c, p point.Point) bool {
+	left := cross(a, b, p) > 0
+	right := cross(b, c, p.AddPoint(c).SubtractPoint(b)) > 0
+	if cross(a, b, c) >= 0 {
+		return left && right
+	}
+	return left || right
+}
+
+// inTriangle returns true if p is inside or on the triangle abc
+func inTriangle(p, a, b, c point.Point) bool {
+	d1 := cross(a, b, p)
+	d2 := cross(b, c, p)
+	d3 := cross(c, a, p)
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(hasNeg && hasPos)
+}
+
+// earClip triangulates a ring that turns clockwise on screen. Vertices may be repeated where
+// holes have been bridged in.
+func earClip(ring []point.Point) [][3]point.Point {
+	idx := make([]int, len(ring))
+	for i := range idx {
+		idx[i] = i
+	}
+	var ret [][3]point.Point
+	for len(idx) > 3 {
+		n := len(idx)
+		clipped := false
+		for i := 0; i < n; i++ {
+			a := ring[idx[(i+n-1)%n]]
+			b := ring[idx[i]]
+			c := ring[idx[(i+1)%n]]
+			c2 := cross(a, b, c)
+			if c2 == 0 {
+				// Drop vertices where the ring goes straight on or doubles back
+				idx = append(idx[:i], idx[i+1:]...)
+				clipped = true
+				break
+			}
+			if c2 < 0 || !isEar(ring, idx, i, a, b, c) {
+				continue
+			}
+			ret = append(ret, [3]point.Point{a, b, c})
+			idx = append(idx[:i], idx[i+1:]...)
+			clipped = true
+			break
+		}
+		if !clipped {
+			// The ring is not simple. Give up rather than produce overlapping triangles.
+			return ret
+		}
+	}
+	if len(idx) == 3 {
+		a, b, c := ring[idx[0]], ring[idx[1]], ring[idx[2]]
+		if cross(a, b, c) > 0 {
+			ret = append(ret, [3]point.Point{a, b, c})
+		}
+	}
+	return ret
+}
+
+// isEar returns true if no other vertex of the ring is inside the triangle abc at vertex i
+func isEar(ring []point.Point, idx []int, i int, a, b, c point.Point) bool {
+	n := len(idx)
+	for j := 0; j < n; j++ {
+		if j == i || j == (i+n-1)%n || j == (i+1)%n {
+			continue
+		}
+		p := ring[idx[j]]
+		if p.Equals(a) || p.Equals(b) || p.Equals(c) {
+			continue
+		}
+		if inTriangle(p, a, b, c) {
+			return false
+		}
+	}
+	return true
+}
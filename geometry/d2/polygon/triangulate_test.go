@@ -0,0 +1,78 @@
+package polygon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func totalArea(polygons []*Polygon) float64 {
+	area := 0.
+	for _, p := range polygons {
+		area += p.Area()
+	}
+	return area
+}
+
+func TestTriangulate(t *testing.T) {
+	t.Run("simple polygons", func(t *testing.T) {
+		star := NewStar(100, 100, 100, 50, 5)
+		triangles := star.Triangulate()
+		assert.Equal(t, 8, len(triangles))
+		assert.InDelta(t, star.Area(), totalArea(triangles), 1e-9)
+		for _, tri := range triangles {
+			assert.Equal(t, 3, len(tri.Segments))
+			assert.True(t, tri.IsClockwise())
+			c := tri.Centroid()
+			assert.True(t, star.ContainsPoint(c.X, c.Y))
+		}
+
+		// Counter-clockwise input
+		reversed := star.Reverse().Triangulate()
+		assert.InDelta(t, star.Area(), totalArea(reversed), 1e-9)
+	})
+
+	t.Run("collinear vertices", func(t *testing.T) {
+		p := NewPolygon(Points{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}})
+		triangles := p.Triangulate()
+		assert.Equal(t, 2, len(triangles))
+		assert.InDelta(t, 4, totalArea(triangles), 1e-9)
+	})
+
+	t.Run("holes", func(t *testing.T) {
+		donut := NewPolygonWithHoles(
+			NewRectangle(0, 0, 10, 10).Polygon,
+			NewRectangle(2, 2, 2, 2).Polygon,
+			NewRectangle(6, 2, 2, 6).Polygon,
+			NewNgon(6, 3, 7, 1),
+		)
+		triangles := donut.Triangulate()
+		// n - 2 + 2h triangles for n vertices and h holes
+		assert.Equal(t, 18-2+6, len(triangles))
+		assert.InDelta(t, donut.Area(), totalArea(triangles), 1e-9)
+		for _, tri := range triangles {
+			c := tri.Centroid()
+			assert.True(t, donut.ContainsPoint(c.X, c.Y))
+		}
+	})
+}
+
+func TestConvexDecomposition(t *testing.T) {
+	u := NewPolygon(Points{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 6}, {X: 6, Y: 6}, {X: 6, Y: 0}, {X: 9, Y: 0}, {X: 9, Y: 9}, {X: 0, Y: 9}})
+	pieces := u.ConvexDecomposition()
+	assert.True(t, len(pieces) <= 4)
+	assert.InDelta(t, u.Area(), totalArea(pieces), 1e-9)
+	for _, piece := range pieces {
+		assert.True(t, piece.IsConvex())
+	}
+
+	square := NewRectangle(0, 0, 10, 10)
+	assert.Equal(t, 1, len(square.ConvexDecomposition()))
+
+	donut := NewPolygonWithHoles(NewRectangle(0, 0, 10, 10).Polygon, NewRectangle(4, 4, 2, 2).Polygon)
+	pieces = donut.ConvexDecomposition()
+	assert.InDelta(t, 96, totalArea(pieces), 1e-9)
+	for _, piece := range pieces {
+		assert.True(t, piece.IsConvex())
+	}
+}
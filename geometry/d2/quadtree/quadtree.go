@@ -0,0 +1,274 @@
+package quadtree
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+)
+
+const (
+	// A node is split when it holds more than maxItems
+	maxItems = 8
+	maxDepth = 16
+)
+
+type entry[T comparable] struct {
+	b     bounds.Bounds
+	value T
+	// seq orders entries by insertion so results are repeatable
+	seq int
+}
+
+type node[T comparable] struct {
+	b        bounds.Bounds
+	depth    int
+	entries  []entry[T]
+	children []*node[T]
+}
+
+// A Quadtree stores values by their bounds for fast range and nearest neighbour queries.
+// Values that fit inside one quadrant of a node are stored further down the tree, so queries
+// only look at the parts of the tree near the area of interest.
+type Quadtree[T comparable] struct {
+	root *node[T]
+	size int
+	seq  int
+}
+
+// New creates an empty quadtree covering the bounds. Values outside of the bounds can still be
+// inserted but are kept at the root.
+func New[T comparable](b bounds.Bounds) *Quadtree[T] {
+	return &Quadtree[T]{root: &node[T]{b: b}}
+}
+
+// An Item is a value that knows its own bounds
+type Item interface {
+	comparable
+	bounds.Bounded
+}
+
+// FromItems creates a quadtree covering the bounds that contains the items
+func FromItems[T Item](b bounds.Bounds, items ...T) *Quadtree[T] {
+	q := New[T](b)
+	for _, item := range items {
+		q.Insert(item.Bounds(), item)
+	}
+	return q
+}
+
+// InsertItem adds an item using its own bounds
+func InsertItem[T Item](q *Quadtree[T], item T) {
+	q.Insert(item.Bounds(), item)
+}
+
+// RemoveItem removes an item that was added with its own bounds
+func RemoveItem[T Item](q *Quadtree[T], item T) bool {
+	return q.Remove(item.Bounds(), item)
+}
+
+// Len returns the number of values in the tree
+func (q *Quadtree[T]) Len() int {
+	return q.size
+}
+
+// Insert adds the value with the bounds
+func (q *Quadtree[T]) Insert(b bounds.Bounds, value T) {
+	q.root.insert(entry[T]{b, value, q.seq})
+	q.seq++
+	q.size++
+}
+
+// InsertPoint adds the value at the point
+func (q *Quadtree[T]) InsertPoint(p point.Point, value T) {
+	q.Insert(pointBounds(p), value)
+}
+
+// Remove removes a value that was inserted with the bounds. It returns false if it was not found.
+func (q *Quadtree[T]) Remove(b bounds.Bounds, value T) bool {
+	if q.root.remove(b, value) {
+		q.size--
+		return true
+	}
+	return false
+}
+
+// RemovePoint removes a value that was inserted at the point
+func (q *Quadtree[T]) RemovePoint(p point.Point, value T) bool {
+	return q.Remove(pointBounds(p), value)
+}
+
+// Query returns the values whose bounds overlap or touch the bounds
+func (q *Quadtree[T]) Query(b bounds.Bounds) []T {
+	var ret []T
+	q.root.visit(b, func(e entry[T]) bool {
+		ret = append(ret, e.value)
+		return true
+	})
+	return ret
+}
+
+// FirstHit returns the first value whose bounds overlap the bounds and for which hit returns
+// true. The search stops as soon as one is found, which makes it a fast collision test.
+func (q *Quadtree[T]) FirstHit(b bounds.Bounds, hit func(value T) bool) (T, bool) {
+	var ret T
+	found := false
+	q.root.visit(b, func(e entry[T]) bool {
+		if hit(e.value) {
+			ret = e.value
+			found = true
+			return false
+		}
+		return true
+	})
+	return ret, found
+}
+
+// Nearest returns up to k values ordered by the distance from p to their bounds, closest first.
+// Values whose bounds contain p have a distance of zero. Ties are returned in insertion order.
+func (q *Quadtree[T]) Nearest(p point.Point, k int) []T {
+	return q.NearestFunc(p, k, func(b bounds.Bounds, value T) float64 {
+		return distance(b, p)
+	})
+}
+
+// NearestFunc returns up to k values ordered by dist, closest first. dist must never be less
+// than the distance from p to the value's bounds, so it can measure the distance to the actual
+// shape of the value.
+func (q *Quadtree[T]) NearestFunc(p point.Point, k int, dist func(b bounds.Bounds, value T) float64) []T {
+	var ret []T
+	if k <= 0 {
+		return ret
+	}
+	// The root is always searched since it can hold values outside of its bounds
+	pq := &queue[T]{{node: q.root}}
+	for pq.Len() > 0 && len(ret) < k {
+		it := heap.Pop(pq).(item[T])
+		if it.node == nil {
+			ret = append(ret, it.entry.value)
+			continue
+		}
+		for _, e := range it.node.entries {
+			heap.Push(pq, item[T]{dist: dist(e.b, e.value), entry: e})
+		}
+		for _, child := range it.node.children {
+			heap.Push(pq, item[T]{dist: distance(child.b, p), node: child})
+		}
+	}
+	return ret
+}
+
+func (n *node[T]) insert(e entry[T]) {
+	if n.children != nil {
+		if child := n.childFor(e.b); child != nil {
+			child.insert(e)
+			return
+		}
+	}
+	n.entries = append(n.entries, e)
+	if n.children == nil && len(n.entries) > maxItems && n.depth < maxDepth {
+		n.split()
+	}
+}
+
+func (n *node[T]) split() {
+	cx := (n.b.Left + n.b.Right) / 2
+	cy := (n.b.Top + n.b.Bottom) / 2
+	n.children = []*node[T]{
+		{b: bounds.NewBounds(n.b.Top, cx, cy, n.b.Left), depth: n.depth + 1},
+		{b: bounds.NewBounds(n.b.Top, n.b.Right, cy, cx), depth: n.depth + 1},
+		{b: bounds.NewBounds(cy, n.b.Right, n.b.Bottom, cx), depth: n.depth + 1},
+		{b: bounds.NewBounds(cy, cx, n.b.Bottom, n.b.Left), depth: n.depth + 1},
+	}
+	entries := n.entries
+	n.entries = nil
+	for _, e := range entries {
+		n.insert(e)
+	}
+}
+
+// childFor returns the child that entirely contains the bounds or nil if none does
+func (n *node[T]) childFor(b bounds.Bounds) *node[T] {
+	for _, child := range n.children {
+		if child.b.ContainsBounds(&b) {
+			return child
+		}
+	}
+	return nil
+}
+
+func (n *node[T]) remove(b bounds.Bounds, value T) bool {
+	for i, e := range n.entries {
+		if e.value == value && e.b == b {
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			return true
+		}
+	}
+	if n.children != nil {
+		if child := n.childFor(b); child != nil {
+			return child.remove(b, value)
+		}
+	}
+	return false
+}
+
+// visit calls fn with each entry that overlaps the bounds until fn returns false
+func (n *node[T]) visit(b bounds.Bounds, fn func(e entry[T]) bool) bool {
+	for _, e := range n.entries {
+		if e.b.OverlapsInclusive(b) && !fn(e) {
+			return false
+		}
+	}
+	for _, child := range n.children {
+		if child.b.OverlapsInclusive(b) && !child.visit(b, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func pointBounds(p point.Point) bounds.Bounds {
+	return bounds.NewBounds(p.Y, p.X, p.Y, p.X)
+}
+
+// distance returns the distance from p to the closest point of the bounds
+func distance(b bounds.Bounds, p point.Point) float64 {
+	dx := math.Max(math.Max(b.Left-p.X, p.X-b.Right), 0)
+	dy := math.Max(math.Max(b.Top-p.Y, p.Y-b.Bottom), 0)
+	return math.Hypot(dx, dy)
+}
+
+// An item in the nearest neighbour search is either a node to search or an entry
+type item[T comparable] struct {
+	dist  float64
+	node  *node[T]
+	entry entry[T]
+}
+
+type queue[T comparable] []item[T]
+
+func (q queue[T]) Len() int { return len(q) }
+
+// Less orders by distance, with nodes first so that every entry at the same distance is found
+// before deciding between them, and then entries in insertion order
+func (q queue[T]) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist < q[j].dist
+	}
+	if (q[i].node == nil) != (q[j].node == nil) {
+		return q[i].node != nil
+	}
+	return q[i].entry.seq < q[j].entry.seq
+}
+
+func (q queue[T]) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *queue[T]) Push(x any) { *q = append(*q, x.(item[T])) }
+
+func (q *queue[T]) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
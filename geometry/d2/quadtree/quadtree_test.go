@@ -0,0 +1,108 @@
+package quadtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomPoints(n int) []point.Point {
+	rng := rand.New(rand.NewSource(1))
+	ret := make([]point.Point, n)
+	for i := range ret {
+		ret[i] = point.NewPoint(rng.Float64()*100, rng.Float64()*100)
+	}
+	return ret
+}
+
+func TestQuery(t *testing.T) {
+	pts := randomPoints(500)
+	q := New[int](bounds.NewBounds(0, 100, 100, 0))
+	for i, p := range pts {
+		q.InsertPoint(p, i)
+	}
+	assert.Equal(t, 500, q.Len())
+
+	area := bounds.NewBounds(20, 60, 50, 10)
+	var want []int
+	for i, p := range pts {
+		if area.Contains(p.X, p.Y) {
+			want = append(want, i)
+		}
+	}
+	got := q.Query(area)
+	sort.Ints(got)
+	assert.Equal(t, want, got)
+}
+
+func TestRemove(t *testing.T) {
+	pts := randomPoints(100)
+	q := New[int](bounds.NewBounds(0, 100, 100, 0))
+	for i, p := range pts {
+		q.InsertPoint(p, i)
+	}
+	for i := 0; i < 100; i += 2 {
+		assert.True(t, q.RemovePoint(pts[i], i))
+	}
+	assert.False(t, q.RemovePoint(pts[0], 0))
+	assert.Equal(t, 50, q.Len())
+	for _, i := range q.Query(bounds.NewBounds(0, 100, 100, 0)) {
+		assert.Equal(t, 1, i%2)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	pts := randomPoints(300)
+	q := New[int](bounds.NewBounds(0, 100, 100, 0))
+	for i, p := range pts {
+		q.InsertPoint(p, i)
+	}
+	// Include a point outside of the tree's bounds
+	q.InsertPoint(point.NewPoint(150, 50), 300)
+	pts = append(pts, point.NewPoint(150, 50))
+
+	target := point.NewPoint(40, 70)
+	want := make([]int, len(pts))
+	for i := range want {
+		want[i] = i
+	}
+	sort.SliceStable(want, func(a, b int) bool {
+		return pts[want[a]].Distance(target) < pts[want[b]].Distance(target)
+	})
+	assert.Equal(t, want[:10], q.Nearest(target, 10))
+	assert.Equal(t, []int{300}, q.Nearest(point.NewPoint(200, 50), 1))
+	assert.Equal(t, 301, len(q.Nearest(target, 1000)))
+}
+
+type box struct {
+	b bounds.Bounds
+}
+
+func (b *box) Bounds() bounds.Bounds {
+	return b.b
+}
+
+func TestItems(t *testing.T) {
+	a := &box{bounds.NewBounds(0, 10, 10, 0)}
+	b := &box{bounds.NewBounds(5, 60, 40, 45)}
+	c := &box{bounds.NewBounds(70, 90, 90, 70)}
+	q := FromItems(bounds.NewBounds(0, 100, 100, 0), a, b, c)
+
+	assert.ElementsMatch(t, []*box{a, b}, q.Query(bounds.NewBounds(8, 50, 12, 8)))
+	assert.Equal(t, []*box{c, b, a}, q.Nearest(point.NewPoint(60, 60), 3))
+
+	hit, ok := q.FirstHit(bounds.NewBounds(0, 100, 100, 0), func(v *box) bool { return v == c })
+	assert.True(t, ok)
+	assert.Equal(t, c, hit)
+	_, ok = q.FirstHit(bounds.NewBounds(0, 20, 20, 0), func(v *box) bool { return v == c })
+	assert.False(t, ok)
+
+	assert.True(t, RemoveItem(q, b))
+	assert.Equal(t, []*box{a}, q.Query(bounds.NewBounds(8, 50, 12, 8)))
+	InsertItem(q, b)
+	assert.Equal(t, 3, q.Len())
+}
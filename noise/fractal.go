@@ -0,0 +1,175 @@
+package noise
+
+import "math"
+
+// Defaults used by Fractal
+const (
+	DefaultOctaves    = 4
+	DefaultLacunarity = 2.
+	DefaultGain       = 0.5
+)
+
+// Fractal controls how octaves of noise are layered.
+type Fractal struct {
+	// Octaves is the number of layers. Defaults to DefaultOctaves.
+	Octaves int
+	// Lacunarity is how much the frequency increases with each octave.
+	// Defaults to DefaultLacunarity.
+	Lacunarity float64
+	// Gain is how much the amplitude decreases with each octave. Defaults to DefaultGain.
+	Gain float64
+}
+
+func (f Fractal) octaves() int {
+	if f.Octaves <= 0 {
+		return DefaultOctaves
+	}
+	return f.Octaves
+}
+
+func (f Fractal) lacunarity() float64 {
+	if f.Lacunarity <= 0 {
+		return DefaultLacunarity
+	}
+	return f.Lacunarity
+}
+
+func (f Fractal) gain() float64 {
+	if f.Gain <= 0 {
+		return DefaultGain
+	}
+	return f.Gain
+}
+
+// sum adds the octaves, each shaped by shape, and scales the result back to the range of one
+// octave. sample returns the noise at a frequency, offset by an amount that differs for each
+// octave so they are not correlated at the origin.
+func (f Fractal) sum(sample func(freq, offset float64) float64, shape func(v float64) float64) float64 {
+	ret, total := 0., 0.
+	freq, amp := 1., 1.
+	for i := 0; i < f.octaves(); i++ {
+		ret += amp * shape(sample(freq, float64(i)*17.31))
+		total += amp
+		freq *= f.lacunarity()
+		amp *= f.gain()
+	}
+	return ret / total
+}
+
+func identity(v float64) float64 {
+	return v
+}
+
+// ridge folds the noise so its zero crossings become sharp peaks at 1
+func ridge(v float64) float64 {
+	v = 1 - math.Abs(v)
+	return v * v
+}
+
+// FBM2 layers octaves of the noise into fractional Brownian motion. The result stays within the
+// range of the noise.
+func FBM2(n Func2, f Fractal) Func2 {
+	return func(x, y float64) float64 {
+		return f.sum(func(freq, o float64) float64 { return n(x*freq+o, y*freq+o) }, identity)
+	}
+}
+
+// FBM3 layers octaves of the noise into fractional Brownian motion
+func FBM3(n Func3, f Fractal) Func3 {
+	return func(x, y, z float64) float64 {
+		return f.sum(func(freq, o float64) float64 { return n(x*freq+o, y*freq+o, z*freq+o) }, identity)
+	}
+}
+
+// FBM4 layers octaves of the noise into fractional Brownian motion
+func FBM4(n Func4, f Fractal) Func4 {
+	return func(x, y, z, w float64) float64 {
+		return f.sum(func(freq, o float64) float64 { return n(x*freq+o, y*freq+o, z*freq+o, w*freq+o) }, identity)
+	}
+}
+
+// Ridged2 layers octaves of folded noise that form sharp ridges like mountain ranges. It varies
+// between 0 and 1.
+func Ridged2(n Func2, f Fractal) Func2 {
+	return func(x, y float64) float64 {
+		return f.sum(func(freq, o float64) float64 { return n(x*freq+o, y*freq+o) }, ridge)
+	}
+}
+
+// Ridged3 layers octaves of folded noise that form sharp ridges. It varies between 0 and 1.
+func Ridged3(n Func3, f Fractal) Func3 {
+	return func(x, y, z float64) float64 {
+		return f.sum(func(freq, o float64) float64 { return n(x*freq+o, y*freq+o, z*freq+o) }, ridge)
+	}
+}
+
+// Turbulence2 layers octaves of the absolute value of the noise, which gives billowy shapes
+// with creases at the zero crossings. It varies between 0 and 1.
+func Turbulence2(n Func2, f Fractal) Func2 {
+	return func(x, y float64) float64 {
+		return f.sum(func(freq, o float64) float64 { return n(x*freq+o, y*freq+o) }, math.Abs)
+	}
+}
+
+// Turbulence3 layers octaves of the absolute value of the noise. It varies between 0 and 1.
+func Turbulence3(n Func3, f Fractal) Func3 {
+	return func(x, y, z float64) float64 {
+		return f.sum(func(freq, o float64) float64 { return n(x*freq+o, y*freq+o, z*freq+o) }, math.Abs)
+	}
+}
+
+// Warp2 displaces the point passed to n by the warp noise times amount. The x and y
+// displacements are taken from distant parts of the warp noise so they are independent.
+// Warping a warp gives the swirling, marbled look of nested domain warping.
+func Warp2(n, warp Func2, amount float64) Func2 {
+	return func(x, y float64) float64 {
+		dx := warp(x, y)
+		dy := warp(x+5.2, y+1.3)
+		return n(x+amount*dx, y+amount*dy)
+	}
+}
+
+// Warp3 displaces the point passed to n by the warp noise times amount
+func Warp3(n, warp Func3, amount float64) Func3 {
+	return func(x, y, z float64) float64 {
+		dx := warp(x, y, z)
+		dy := warp(x+5.2, y+1.3, z+2.8)
+		dz := warp(x+1.7, y+9.2, z+4.1)
+		return n(x+amount*dx, y+amount*dy, z+amount*dz)
+	}
+}
+
+// circle maps v onto a circle whose circumference is period, so that moving v by period comes
+// back to the same point
+func circle(v, period float64) (float64, float64) {
+	r := period / (2 * math.Pi)
+	a := v / r
+	return r * math.Cos(a), r * math.Sin(a)
+}
+
+// Tile2 returns 2D noise that repeats every width along x and every height along y, by walking
+// around two circles in 4D noise. The noise has the same scale as n.
+func Tile2(n Func4, width, height float64) Func2 {
+	return func(x, y float64) float64 {
+		x1, x2 := circle(x, width)
+		y1, y2 := circle(y, height)
+		return n(x1, x2, y1, y2)
+	}
+}
+
+// Loop3 returns 2D noise that changes smoothly with time z and loops back to the start after
+// period. Use it for animations that repeat seamlessly.
+func Loop3(n Func4, period float64) Func3 {
+	return func(x, y, z float64) float64 {
+		z1, z2 := circle(z, period)
+		return n(x, y, z1, z2)
+	}
+}
+
+// Loop1 returns 1D noise that repeats every period, such as offsets for the points around a
+// closed shape.
+func Loop1(n Func2, period float64) func(t float64) float64 {
+	return func(t float64) float64 {
+		return n(circle(t, period))
+	}
+}
@@ -0,0 +1,73 @@
+// Package noise provides seedable gradient and value noise along with fractal, warping and
+// tiling helpers. Noise functions have the same signature as the fields taken by the geometry
+// packages, so they can be passed straight to helpers like hatch.Shade.
+package noise
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Func2 is noise in two dimensions
+type Func2 = func(x, y float64) float64
+
+// Func3 is noise in three dimensions
+type Func3 = func(x, y, z float64) float64
+
+// Func4 is noise in four dimensions
+type Func4 = func(x, y, z, w float64) float64
+
+// perm is a shuffled table of the numbers 0 to 255, repeated so that sums of two entries can
+// be looked up without wrapping
+type perm [512]int
+
+func newPerm(seed int64) perm {
+	var p perm
+	for i, v := range rand.New(rand.NewSource(seed)).Perm(256) {
+		p[i] = v
+		p[i+256] = v
+	}
+	return p
+}
+
+func (p *perm) hash2(i, j int) int {
+	return p[p[i&255]+j&255]
+}
+
+func (p *perm) hash3(i, j, k int) int {
+	return p[p[p[i&255]+j&255]+k&255]
+}
+
+func (p *perm) hash4(i, j, k, l int) int {
+	return p[p[p[p[i&255]+j&255]+k&255]+l&255]
+}
+
+// fade eases t from 0 to 1 with zero first and second derivatives at each end
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + t*(b-a)
+}
+
+func floor(x float64) (int, float64) {
+	f := math.Floor(x)
+	return int(f), x - f
+}
+
+// interpolate blends the values at the corners of a cell in up to four dimensions, one axis at
+// a time. s holds the faded position of the point within the cell along each axis.
+func interpolate(s [4]float64, dims int, corner func(c [4]int) float64) float64 {
+	var values [16]float64
+	size := 1 << dims
+	for c := 0; c < size; c++ {
+		values[c] = corner([4]int{c & 1, c >> 1 & 1, c >> 2 & 1, c >> 3 & 1})
+	}
+	for axis := 0; size > 1; axis, size = axis+1, size/2 {
+		for c := 0; c < size/2; c++ {
+			values[c] = lerp(values[2*c], values[2*c+1], s[axis])
+		}
+	}
+	return values[0]
+}
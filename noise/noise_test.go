@@ -0,0 +1,121 @@
+package noise
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type noises struct {
+	name string
+	n2   Func2
+	n3   Func3
+	n4   Func4
+}
+
+func all(seed int64) []noises {
+	p, s, o, v := NewPerlin(seed), NewSimplex(seed), NewOpenSimplex(seed), NewValue(seed)
+	return []noises{
+		{"perlin", p.Eval2, p.Eval3, p.Eval4},
+		{"simplex", s.Eval2, s.Eval3, s.Eval4},
+		{"opensimplex", o.Eval2, o.Eval3, o.Eval4},
+		{"value", v.Eval2, v.Eval3, v.Eval4},
+	}
+}
+
+func TestNoise(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a, b := all(1), all(2)
+	for i, n := range a {
+		t.Run(n.name, func(t *testing.T) {
+			lo, hi := math.Inf(1), math.Inf(-1)
+			differ := false
+			for k := 0; k < 5000; k++ {
+				x, y, z, w := rng.Float64()*50-25, rng.Float64()*50-25, rng.Float64()*50-25, rng.Float64()*50-25
+				for _, v := range []float64{n.n2(x, y), n.n3(x, y, z), n.n4(x, y, z, w)} {
+					lo = math.Min(lo, v)
+					hi = math.Max(hi, v)
+				}
+				assert.Equal(t, n.n2(x, y), all(1)[i].n2(x, y))
+				differ = differ || n.n2(x, y) != b[i].n2(x, y)
+
+				// Continuous
+				assert.InDelta(t, n.n2(x, y), n.n2(x+1e-6, y), 1e-4)
+				assert.InDelta(t, n.n3(x, y, z), n.n3(x, y, z+1e-6), 1e-4)
+				assert.InDelta(t, n.n4(x, y, z, w), n.n4(x, y, z, w+1e-6), 1e-4)
+			}
+			assert.True(t, differ)
+			assert.GreaterOrEqual(t, lo, -1.1)
+			assert.LessOrEqual(t, hi, 1.1)
+			// Uses most of the range
+			assert.Less(t, lo, -0.5)
+			assert.Greater(t, hi, 0.5)
+		})
+	}
+
+	t.Run("opensimplex is not simplex", func(t *testing.T) {
+		assert.NotEqual(t, NewSimplex(0).Eval2(0.3, 0.4), NewOpenSimplex(0).Eval2(0.3, 0.4))
+		// Each point of the lattice is zero and the noise reaches its full range in between
+		o := NewOpenSimplex(0)
+		assert.Equal(t, 0., o.Eval2(0, 0))
+		lo, hi := 0., 0.
+		for i := 0; i < 200; i++ {
+			for j := 0; j < 200; j++ {
+				v := o.Eval2(float64(i)/20, float64(j)/20)
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+		assert.Less(t, lo, -0.8)
+		assert.Greater(t, hi, 0.8)
+	})
+
+	t.Run("perlin is zero on the grid", func(t *testing.T) {
+		p := NewPerlin(0)
+		assert.Equal(t, 0., p.Eval2(3, -7))
+		assert.Equal(t, 0., p.Eval3(3, -7, 2))
+		assert.Equal(t, 0., p.Eval4(3, -7, 2, 9))
+	})
+}
+
+func TestFractal(t *testing.T) {
+	n := NewSimplex(0)
+	fbm := FBM2(n.Eval2, Fractal{Octaves: 6})
+	ridged := Ridged2(n.Eval2, Fractal{})
+	turbulence := Turbulence2(n.Eval2, Fractal{})
+	rng := rand.New(rand.NewSource(1))
+	for k := 0; k < 2000; k++ {
+		x, y := rng.Float64()*20, rng.Float64()*20
+		v := fbm(x, y)
+		assert.True(t, v >= -1 && v <= 1)
+		v = ridged(x, y)
+		assert.True(t, v >= 0 && v <= 1)
+		v = turbulence(x, y)
+		assert.True(t, v >= 0 && v <= 1)
+	}
+	// One octave is the noise itself
+	assert.Equal(t, n.Eval2(0.3, 0.4), FBM2(n.Eval2, Fractal{Octaves: 1})(0.3, 0.4))
+	assert.Equal(t, n.Eval3(0.3, 0.4, 0.5), FBM3(n.Eval3, Fractal{Octaves: 1})(0.3, 0.4, 0.5))
+}
+
+func TestWarp(t *testing.T) {
+	n := NewPerlin(0)
+	assert.Equal(t, n.Eval2(1.3, 2.7), Warp2(n.Eval2, n.Eval2, 0)(1.3, 2.7))
+	assert.NotEqual(t, n.Eval2(1.3, 2.7), Warp2(n.Eval2, n.Eval2, 2)(1.3, 2.7))
+	assert.Equal(t, n.Eval3(1.3, 2.7, 0.2), Warp3(n.Eval3, n.Eval3, 0)(1.3, 2.7, 0.2))
+}
+
+func TestTile(t *testing.T) {
+	n := NewSimplex(3)
+	tile := Tile2(n.Eval4, 10, 4)
+	loop := Loop3(n.Eval4, 6)
+	loop1 := Loop1(n.Eval2, 8)
+	for _, x := range []float64{0, 1.5, 3.3, 7.9} {
+		assert.InDelta(t, tile(x, 1.2), tile(x+10, 1.2), 1e-9)
+		assert.InDelta(t, tile(x, 1.2), tile(x, 1.2+4), 1e-9)
+		assert.InDelta(t, loop(x, 2, 1), loop(x, 2, 7), 1e-9)
+		assert.InDelta(t, loop1(x), loop1(x+8), 1e-9)
+	}
+	assert.NotEqual(t, tile(0, 0), tile(5, 0))
+}
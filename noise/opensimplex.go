@@ -0,0 +1,348 @@
+package noise
+
+import "math"
+
+// OpenSimplex is Kurt Spencer's OpenSimplex2 noise. Like simplex noise it is built on a
+// triangular lattice rather than a grid of squares, but its lattices and gradients are chosen so
+// that it shows fewer directional artifacts. It varies between roughly -1 and 1.
+type OpenSimplex struct {
+	seed int64
+}
+
+// NewOpenSimplex creates OpenSimplex2 noise. The same seed always gives the same noise.
+func NewOpenSimplex(seed int64) *OpenSimplex {
+	return &OpenSimplex{seed: seed}
+}
+
+// Primes for hashing lattice points and the offsets between the seeds of lattice copies
+const (
+	primeX         int64 = 0x5205402B9270C86F
+	primeY         int64 = 0x598CD327003817B5
+	primeZ         int64 = 0x5BCC226E9FA0BACB
+	primeW         int64 = 0x56CC5227E58F554B
+	hashMultiplier int64 = 0x53A3F72DEEC546F5
+	seedFlip3      int64 = -0x52D547B2E96ED629
+	seedOffset4    int64 = 0xE83DC3E0DA7164D
+)
+
+// Lattice transforms. The 2D lattice is skewed onto a square grid, the 3D lattice is two
+// interleaved cubic grids seen along their diagonal and the 4D lattice is five copies of a
+// skewed hypercubic grid.
+const (
+	skew2        = 0.366025403784439
+	unskew2      = -0.21132486540518713
+	root3Over3   = 0.577350269189626
+	skew4        = -0.138196601125011
+	unskew4      = 0.309016994374947
+	latticeStep4 = 0.2
+)
+
+// Radii of the kernels around each lattice point and the scales that bring the sums of the
+// kernels to roughly -1 to 1
+const (
+	radius2     = 0.5
+	radius3     = 0.6
+	radius4     = 0.6
+	normalizer2 = 0.01001634121365712
+	normalizer3 = 0.07969837668935331
+	normalizer4 = 0.0220065933241897
+)
+
+// The gradient tables are repeated to these sizes, which are powers of two so that hashes can
+// be masked into them
+const (
+	gradsExp2 = 7
+	gradsExp3 = 8
+	gradsExp4 = 9
+)
+
+var (
+	openGrads2 = openGradients(gradients2(), 2, 1<<gradsExp2, normalizer2)
+	openGrads3 = openGradients(gradients3(), 3, 1<<gradsExp3, normalizer3)
+	openGrads4 = openGradients(gradients4(), 4, 1<<gradsExp4, normalizer4)
+)
+
+// gradients2 returns 24 unit vectors, eight of them a quarter of the way between the axes and
+// diagonals and sixteen spread either side of the axes
+func gradients2() [][]float64 {
+	var ret [][]float64
+	for i := 0; i < 8; i++ {
+		a := (67.5 - 45*float64(i)) * math.Pi / 180
+		ret = append(ret, []float64{math.Cos(a), math.Sin(a)})
+	}
+	for i := 0; i < 4; i++ {
+		for _, d := range []float64{82.5, 52.5, 37.5, 7.5} {
+			a := (d - 90*float64(i)) * math.Pi / 180
+			ret = append(ret, []float64{math.Cos(a), math.Sin(a)})
+		}
+	}
+	return ret
+}
+
+// gradients3 returns 48 vectors of equal length. Half have two large components and one small
+// one and half have a zero component, which together avoid lining up with the lattice.
+func gradients3() [][]float64 {
+	const big, small = 2.22474487139, 1.
+	const long, short = 3.0862664687972017, 1.1721513422464978
+	var ret [][]float64
+	signs := []float64{1, -1}
+	for axis := 0; axis < 3; axis++ {
+		others := [2]int{(axis + 1) % 3, (axis + 2) % 3}
+		for _, sa := range signs {
+			for _, sb := range signs {
+				for _, sc := range signs {
+					v := make([]float64, 3)
+					v[axis], v[others[0]], v[others[1]] = sa*small, sb*big, sc*big
+					ret = append(ret, v)
+
+					// sa picks which of the other axes is long when axis is zero
+					w := make([]float64, 3)
+					w[others[0]], w[others[1]] = sb*long, sc*short
+					if sa < 0 {
+						w[others[0]], w[others[1]] = sb*short, sc*long
+					}
+					ret = append(ret, w)
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// gradients4 returns the 48 unit vectors toward the vertices of a 24-cell and of its dual,
+// which spread evenly enough in 4D that no direction is favoured
+func gradients4() [][]float64 {
+	var ret [][]float64
+	signs := []float64{1, -1}
+	// Pairs of axes, which give the vertices of the first 24-cell
+	for a := 0; a < 4; a++ {
+		for b := a + 1; b < 4; b++ {
+			for _, sa := range signs {
+				for _, sb := range signs {
+					v := make([]float64, 4)
+					v[a], v[b] = sa*math.Sqrt2/2, sb*math.Sqrt2/2
+					ret = append(ret, v)
+				}
+			}
+		}
+	}
+	// The axes and the corners of the tesseract, which give the vertices of its dual
+	for a := 0; a < 4; a++ {
+		for _, s := range signs {
+			v := make([]float64, 4)
+			v[a] = s
+			ret = append(ret, v)
+		}
+	}
+	for c := 0; c < 16; c++ {
+		v := make([]float64, 4)
+		for a := range v {
+			v[a] = 0.5
+			if c>>a&1 != 0 {
+				v[a] = -0.5
+			}
+		}
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// openGradients scales the gradients by one over the normalizer and repeats them into a table of
+// n entries. Each entry takes a power of two slots so that it can be found by masking.
+func openGradients(grads [][]float64, dims, n int, normalizer float64) []float64 {
+	stride := 2
+	if dims > 2 {
+		stride = 4
+	}
+	ret := make([]float64, n*stride)
+	for i := 0; i < n; i++ {
+		for d, v := range grads[i%len(grads)] {
+			ret[i*stride+d] = v / normalizer
+		}
+	}
+	return ret
+}
+
+func openGrad2(seed, xp, yp int64, dx, dy float64) float64 {
+	hash := (seed ^ xp ^ yp) * hashMultiplier
+	hash ^= hash >> (64 - gradsExp2 + 1)
+	gi := int(hash) & ((1<<gradsExp2 - 1) << 1)
+	return openGrads2[gi]*dx + openGrads2[gi|1]*dy
+}
+
+func openGrad3(seed, xp, yp, zp int64, dx, dy, dz float64) float64 {
+	hash := ((seed ^ xp) ^ (yp ^ zp)) * hashMultiplier
+	hash ^= hash >> (64 - gradsExp3 + 2)
+	gi := int(hash) & ((1<<gradsExp3 - 1) << 2)
+	return openGrads3[gi]*dx + openGrads3[gi|1]*dy + openGrads3[gi|2]*dz
+}
+
+func openGrad4(seed, xp, yp, zp, wp int64, dx, dy, dz, dw float64) float64 {
+	hash := (seed ^ (xp ^ yp) ^ (zp ^ wp)) * hashMultiplier
+	hash ^= hash >> (64 - gradsExp4 + 2)
+	gi := int(hash) & ((1<<gradsExp4 - 1) << 2)
+	return openGrads4[gi]*dx + openGrads4[gi|1]*dy + openGrads4[gi|2]*dz + openGrads4[gi|3]*dw
+}
+
+// Eval2 returns the noise at x, y
+func (n *OpenSimplex) Eval2(x, y float64) float64 {
+	// Skew onto a square grid where each square is two triangles of the lattice
+	s := skew2 * (x + y)
+	xs, ys := x+s, y+s
+	xsb, xi := floor(xs)
+	ysb, yi := floor(ys)
+	xp, yp := int64(xsb)*primeX, int64(ysb)*primeY
+
+	t := (xi + yi) * unskew2
+	dx0, dy0 := xi+t, yi+t
+
+	ret := 0.
+	a0 := radius2 - dx0*dx0 - dy0*dy0
+	if a0 > 0 {
+		ret = a0 * a0 * a0 * a0 * openGrad2(n.seed, xp, yp, dx0, dy0)
+	}
+
+	// The far corner of the square
+	const k = 1 + 2*unskew2
+	a1 := 2*k*(1/unskew2+2)*t + (-2*k*k + a0)
+	if a1 > 0 {
+		ret += a1 * a1 * a1 * a1 * openGrad2(n.seed, xp+primeX, yp+primeY, dx0-k, dy0-k)
+	}
+
+	// The corner of whichever triangle the point is in
+	if dy0 > dx0 {
+		dx2, dy2 := dx0-unskew2, dy0-(unskew2+1)
+		if a2 := radius2 - dx2*dx2 - dy2*dy2; a2 > 0 {
+			ret += a2 * a2 * a2 * a2 * openGrad2(n.seed, xp, yp+primeY, dx2, dy2)
+		}
+	} else {
+		dx2, dy2 := dx0-(unskew2+1), dy0-unskew2
+		if a2 := radius2 - dx2*dx2 - dy2*dy2; a2 > 0 {
+			ret += a2 * a2 * a2 * a2 * openGrad2(n.seed, xp+primeX, yp, dx2, dy2)
+		}
+	}
+	return ret
+}
+
+// Eval3 returns the noise at x, y, z. The lattice is turned so that slices of constant z look
+// their best, which suits z being time or depth.
+func (n *OpenSimplex) Eval3(x, y, z float64) float64 {
+	xy := x + y
+	s2 := xy * unskew2
+	zz := z * root3Over3
+	xr, yr, zr := x+s2+zz, y+s2+zz, xy*-root3Over3+zz
+
+	xrb, yrb, zrb := math.Round(xr), math.Round(yr), math.Round(zr)
+	xri, yri, zri := xr-xrb, yr-yrb, zr-zrb
+	// -1 if the point is past the nearest lattice point along the axis and 1 if it is before it
+	xSign, ySign, zSign := int64(int(-1-xri)|1), int64(int(-1-yri)|1), int64(int(-1-zri)|1)
+	ax, ay, az := float64(xSign)*-xri, float64(ySign)*-yri, float64(zSign)*-zri
+	xp, yp, zp := int64(xrb)*primeX, int64(yrb)*primeY, int64(zrb)*primeZ
+
+	seed := n.seed
+	ret := 0.
+	a := (radius3 - xri*xri) - (yri*yri + zri*zri)
+	// The nearest lattice point and the next nearest along one axis, in each of the two grids
+	for l := 0; ; l++ {
+		if a > 0 {
+			ret += a * a * a * a * openGrad3(seed, xp, yp, zp, xri, yri, zri)
+		}
+		if ax >= ay && ax >= az {
+			if b := a + ax + ax; b > 1 {
+				b--
+				ret += b * b * b * b * openGrad3(seed, xp-xSign*primeX, yp, zp, xri+float64(xSign), yri, zri)
+			}
+		} else if ay > ax && ay >= az {
+			if b := a + ay + ay; b > 1 {
+				b--
+				ret += b * b * b * b * openGrad3(seed, xp, yp-ySign*primeY, zp, xri, yri+float64(ySign), zri)
+			}
+		} else {
+			if b := a + az + az; b > 1 {
+				b--
+				ret += b * b * b * b * openGrad3(seed, xp, yp, zp-zSign*primeZ, xri, yri, zri+float64(zSign))
+			}
+		}
+		if l == 1 {
+			break
+		}
+
+		// Move to the second grid, which is offset by half a cell
+		ax, ay, az = 0.5-ax, 0.5-ay, 0.5-az
+		xri, yri, zri = float64(xSign)*ax, float64(ySign)*ay, float64(zSign)*az
+		a += (0.75 - ax) - (ay + az)
+		xp += (xSign >> 1) & primeX
+		yp += (ySign >> 1) & primeY
+		zp += (zSign >> 1) & primeZ
+		xSign, ySign, zSign = -xSign, -ySign, -zSign
+		seed ^= seedFlip3
+	}
+	return ret
+}
+
+// Eval4 returns the noise at x, y, z, w
+func (n *OpenSimplex) Eval4(x, y, z, w float64) float64 {
+	s := skew4 * (x + y + z + w)
+	xsb, xsi := floor(x + s)
+	ysb, ysi := floor(y + s)
+	zsb, zsi := floor(z + s)
+	wsb, wsi := floor(w + s)
+
+	// Start on the copy of the lattice that is sure to have a point near the base of the cell
+	siSum := (xsi + ysi) + (zsi + wsi)
+	start := int(siSum * 1.25)
+	seed := n.seed + int64(start)*seedOffset4
+	offset := float64(start) * -latticeStep4
+	xsi, ysi, zsi, wsi = xsi+offset, ysi+offset, zsi+offset, wsi+offset
+	ssi := (siSum + offset*4) * unskew4
+	xp, yp, zp, wp := int64(xsb)*primeX, int64(ysb)*primeY, int64(zsb)*primeZ, int64(wsb)*primeW
+
+	ret := 0.
+	// One point from each of the five copies of the lattice
+	for i := 0; ; i++ {
+		// Step to the closest vertex of the simplex whose base is the current point
+		score := 1 + ssi*(-1/unskew4)
+		switch {
+		case xsi >= ysi && xsi >= zsi && xsi >= wsi && xsi >= score:
+			xp += primeX
+			xsi--
+			ssi -= unskew4
+		case ysi > xsi && ysi >= zsi && ysi >= wsi && ysi >= score:
+			yp += primeY
+			ysi--
+			ssi -= unskew4
+		case zsi > xsi && zsi > ysi && zsi >= wsi && zsi >= score:
+			zp += primeZ
+			zsi--
+			ssi -= unskew4
+		case wsi > xsi && wsi > ysi && wsi > zsi && wsi >= score:
+			wp += primeW
+			wsi--
+			ssi -= unskew4
+		}
+
+		dx, dy, dz, dw := xsi+ssi, ysi+ssi, zsi+ssi, wsi+ssi
+		if a := (dx*dx + dy*dy) + (dz*dz + dw*dw); a < radius4 {
+			a -= radius4
+			a *= a
+			ret += a * a * openGrad4(seed, xp, yp, zp, wp, dx, dy, dz, dw)
+		}
+		if i == 4 {
+			break
+		}
+
+		// The next copy of the lattice is shifted down by a fifth of a cell along every axis
+		xsi, ysi, zsi, wsi = xsi+latticeStep4, ysi+latticeStep4, zsi+latticeStep4, wsi+latticeStep4
+		ssi += latticeStep4 * 4 * unskew4
+		seed -= seedOffset4
+		// Moving past the first copy wraps around to the last, a whole cell down
+		if i == start {
+			xp -= primeX
+			yp -= primeY
+			zp -= primeZ
+			wp -= primeW
+			seed += seedOffset4 * 5
+		}
+	}
+	return ret
+}
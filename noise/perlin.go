@@ -0,0 +1,110 @@
+package noise
+
+import "math"
+
+// Perlin is Ken Perlin's improved gradient noise. It is zero at every integer coordinate and
+// varies between roughly -1 and 1.
+type Perlin struct {
+	p perm
+}
+
+// NewPerlin creates Perlin noise. The same seed always gives the same noise.
+func NewPerlin(seed int64) *Perlin {
+	return &Perlin{p: newPerm(seed)}
+}
+
+// grad2 returns the dot product of x, y with one of eight unit gradients
+func grad2(h int, x, y float64) float64 {
+	a := float64(h&7) * math.Pi / 4
+	return math.Cos(a)*x + math.Sin(a)*y
+}
+
+// grad3 returns the dot product of x, y, z with one of the twelve edge directions of a cube
+func grad3(h int, x, y, z float64) float64 {
+	h &= 15
+	u := y
+	if h < 8 {
+		u = x
+	}
+	v := z
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 {
+		v = x
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
+
+// grad4 returns the dot product of x, y, z, w with one of the 32 edge directions of a
+// tesseract
+func grad4(h int, x, y, z, w float64) float64 {
+	h &= 31
+	u, v, t := x, y, z
+	if h >= 24 {
+		u = y
+	}
+	if h >= 16 {
+		v = z
+	}
+	if h >= 8 {
+		t = w
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	if h&4 != 0 {
+		t = -t
+	}
+	return u + v + t
+}
+
+// Eval2 returns the noise at x, y
+func (n *Perlin) Eval2(x, y float64) float64 {
+	i, fx := floor(x)
+	j, fy := floor(y)
+	u, v := fade(fx), fade(fy)
+	p := &n.p
+	return math.Sqrt2 * lerp(
+		lerp(grad2(p.hash2(i, j), fx, fy), grad2(p.hash2(i+1, j), fx-1, fy), u),
+		lerp(grad2(p.hash2(i, j+1), fx, fy-1), grad2(p.hash2(i+1, j+1), fx-1, fy-1), u),
+		v,
+	)
+}
+
+// Eval3 returns the noise at x, y, z
+func (n *Perlin) Eval3(x, y, z float64) float64 {
+	i, fx := floor(x)
+	j, fy := floor(y)
+	k, fz := floor(z)
+	u, v, w := fade(fx), fade(fy), fade(fz)
+	p := &n.p
+	corner := func(di, dj, dk int) float64 {
+		return grad3(p.hash3(i+di, j+dj, k+dk), fx-float64(di), fy-float64(dj), fz-float64(dk))
+	}
+	return lerp(
+		lerp(lerp(corner(0, 0, 0), corner(1, 0, 0), u), lerp(corner(0, 1, 0), corner(1, 1, 0), u), v),
+		lerp(lerp(corner(0, 0, 1), corner(1, 0, 1), u), lerp(corner(0, 1, 1), corner(1, 1, 1), u), v),
+		w,
+	)
+}
+
+// Eval4 returns the noise at x, y, z, w
+func (n *Perlin) Eval4(x, y, z, w float64) float64 {
+	i, fx := floor(x)
+	j, fy := floor(y)
+	k, fz := floor(z)
+	l, fw := floor(w)
+	s := [4]float64{fade(fx), fade(fy), fade(fz), fade(fw)}
+	return interpolate(s, 4, func(c [4]int) float64 {
+		return grad4(n.p.hash4(i+c[0], j+c[1], k+c[2], l+c[3]), fx-float64(c[0]), fy-float64(c[1]), fz-float64(c[2]), fw-float64(c[3]))
+	}) / 1.5
+}
@@ -0,0 +1,173 @@
+package noise
+
+import "math"
+
+// Simplex is Ken Perlin's classic simplex noise, following Stefan Gustavson's implementation.
+// It is cheaper than Perlin noise in higher dimensions and has no visible grid. It is not the
+// same noise as OpenSimplex and looks different. It varies between roughly -1 and 1.
+type Simplex struct {
+	p perm
+}
+
+// NewSimplex creates simplex noise. The same seed always gives the same noise.
+func NewSimplex(seed int64) *Simplex {
+	return &Simplex{p: newPerm(seed)}
+}
+
+var simplexGrad3 = [12][3]float64{
+	{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+	{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+	{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+}
+
+var simplexGrad4 = [32][4]float64{
+	{0, 1, 1, 1}, {0, 1, 1, -1}, {0, 1, -1, 1}, {0, 1, -1, -1},
+	{0, -1, 1, 1}, {0, -1, 1, -1}, {0, -1, -1, 1}, {0, -1, -1, -1},
+	{1, 0, 1, 1}, {1, 0, 1, -1}, {1, 0, -1, 1}, {1, 0, -1, -1},
+	{-1, 0, 1, 1}, {-1, 0, 1, -1}, {-1, 0, -1, 1}, {-1, 0, -1, -1},
+	{1, 1, 0, 1}, {1, 1, 0, -1}, {1, -1, 0, 1}, {1, -1, 0, -1},
+	{-1, 1, 0, 1}, {-1, 1, 0, -1}, {-1, -1, 0, 1}, {-1, -1, 0, -1},
+	{1, 1, 1, 0}, {1, 1, -1, 0}, {1, -1, 1, 0}, {1, -1, -1, 0},
+	{-1, 1, 1, 0}, {-1, 1, -1, 0}, {-1, -1, 1, 0}, {-1, -1, -1, 0},
+}
+
+// Skewing factors that map the simplex grid onto a grid of hypercubes and back
+var (
+	f2 = (math.Sqrt(3) - 1) / 2
+	g2 = (3 - math.Sqrt(3)) / 6
+	f3 = 1. / 3
+	g3 = 1. / 6
+	f4 = (math.Sqrt(5) - 1) / 4
+	g4 = (5 - math.Sqrt(5)) / 20
+)
+
+// Eval2 returns the noise at x, y
+func (n *Simplex) Eval2(x, y float64) float64 {
+	s := (x + y) * f2
+	i := int(math.Floor(x + s))
+	j := int(math.Floor(y + s))
+	t := float64(i+j) * g2
+	x0 := x - (float64(i) - t)
+	y0 := y - (float64(j) - t)
+
+	// The second corner is along whichever axis the point is further along
+	i1, j1 := 0, 1
+	if x0 > y0 {
+		i1, j1 = 1, 0
+	}
+	corners := [3][2]float64{
+		{x0, y0},
+		{x0 - float64(i1) + g2, y0 - float64(j1) + g2},
+		{x0 - 1 + 2*g2, y0 - 1 + 2*g2},
+	}
+	offsets := [3][2]int{{0, 0}, {i1, j1}, {1, 1}}
+
+	ret := 0.
+	for c, d := range corners {
+		r := 0.5 - d[0]*d[0] - d[1]*d[1]
+		if r <= 0 {
+			continue
+		}
+		g := simplexGrad3[n.p.hash2(i+offsets[c][0], j+offsets[c][1])%12]
+		r *= r
+		ret += r * r * (g[0]*d[0] + g[1]*d[1])
+	}
+	return 70 * ret
+}
+
+// Eval3 returns the noise at x, y, z
+func (n *Simplex) Eval3(x, y, z float64) float64 {
+	s := (x + y + z) * f3
+	i := int(math.Floor(x + s))
+	j := int(math.Floor(y + s))
+	k := int(math.Floor(z + s))
+	t := float64(i+j+k) * g3
+	x0 := x - (float64(i) - t)
+	y0 := y - (float64(j) - t)
+	z0 := z - (float64(k) - t)
+
+	// The simplex is found by walking the axes from the one the point is furthest along
+	var o1, o2 [3]int
+	if x0 >= y0 {
+		if y0 >= z0 {
+			o1, o2 = [3]int{1, 0, 0}, [3]int{1, 1, 0}
+		} else if x0 >= z0 {
+			o1, o2 = [3]int{1, 0, 0}, [3]int{1, 0, 1}
+		} else {
+			o1, o2 = [3]int{0, 0, 1}, [3]int{1, 0, 1}
+		}
+	} else {
+		if y0 < z0 {
+			o1, o2 = [3]int{0, 0, 1}, [3]int{0, 1, 1}
+		} else if x0 < z0 {
+			o1, o2 = [3]int{0, 1, 0}, [3]int{0, 1, 1}
+		} else {
+			o1, o2 = [3]int{0, 1, 0}, [3]int{1, 1, 0}
+		}
+	}
+	offsets := [4][3]int{{0, 0, 0}, o1, o2, {1, 1, 1}}
+
+	ret := 0.
+	for c, o := range offsets {
+		d := [3]float64{
+			x0 - float64(o[0]) + float64(c)*g3,
+			y0 - float64(o[1]) + float64(c)*g3,
+			z0 - float64(o[2]) + float64(c)*g3,
+		}
+		r := 0.6 - d[0]*d[0] - d[1]*d[1] - d[2]*d[2]
+		if r <= 0 {
+			continue
+		}
+		g := simplexGrad3[n.p.hash3(i+o[0], j+o[1], k+o[2])%12]
+		r *= r
+		ret += r * r * (g[0]*d[0] + g[1]*d[1] + g[2]*d[2])
+	}
+	return 32 * ret
+}
+
+// Eval4 returns the noise at x, y, z, w
+func (n *Simplex) Eval4(x, y, z, w float64) float64 {
+	s := (x + y + z + w) * f4
+	cell := [4]int{int(math.Floor(x + s)), int(math.Floor(y + s)), int(math.Floor(z + s)), int(math.Floor(w + s))}
+	t := float64(cell[0]+cell[1]+cell[2]+cell[3]) * g4
+	d0 := [4]float64{
+		x - (float64(cell[0]) - t),
+		y - (float64(cell[1]) - t),
+		z - (float64(cell[2]) - t),
+		w - (float64(cell[3]) - t),
+	}
+
+	// Rank the axes by how far along them the point is. The simplex steps along the highest
+	// ranked axis first.
+	var rank [4]int
+	for a := 0; a < 4; a++ {
+		for b := a + 1; b < 4; b++ {
+			if d0[a] > d0[b] {
+				rank[a]++
+			} else {
+				rank[b]++
+			}
+		}
+	}
+
+	ret := 0.
+	for c := 0; c < 5; c++ {
+		var o [4]int
+		var d [4]float64
+		r := 0.6
+		for a := range o {
+			if rank[a] >= 4-c {
+				o[a] = 1
+			}
+			d[a] = d0[a] - float64(o[a]) + float64(c)*g4
+			r -= d[a] * d[a]
+		}
+		if r <= 0 {
+			continue
+		}
+		g := simplexGrad4[n.p.hash4(cell[0]+o[0], cell[1]+o[1], cell[2]+o[2], cell[3]+o[3])%32]
+		r *= r
+		ret += r * r * (g[0]*d[0] + g[1]*d[1] + g[2]*d[2] + g[3]*d[3])
+	}
+	return 27 * ret
+}
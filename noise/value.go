@@ -0,0 +1,52 @@
+package noise
+
+// Value is value noise. Each integer coordinate is given a random value and the values are
+// smoothly interpolated between them. It is blockier than gradient noise and varies between -1
+// and 1.
+type Value struct {
+	p perm
+}
+
+// NewValue creates value noise. The same seed always gives the same noise.
+func NewValue(seed int64) *Value {
+	return &Value{p: newPerm(seed)}
+}
+
+// value maps a hash to the range -1 to 1
+func value(h int) float64 {
+	return float64(h)/127.5 - 1
+}
+
+// Eval2 returns the noise at x, y
+func (n *Value) Eval2(x, y float64) float64 {
+	i, fx := floor(x)
+	j, fy := floor(y)
+	u, v := fade(fx), fade(fy)
+	p := &n.p
+	return lerp(
+		lerp(value(p.hash2(i, j)), value(p.hash2(i+1, j)), u),
+		lerp(value(p.hash2(i, j+1)), value(p.hash2(i+1, j+1)), u),
+		v,
+	)
+}
+
+// Eval3 returns the noise at x, y, z
+func (n *Value) Eval3(x, y, z float64) float64 {
+	i, fx := floor(x)
+	j, fy := floor(y)
+	k, fz := floor(z)
+	return interpolate([4]float64{fade(fx), fade(fy), fade(fz)}, 3, func(c [4]int) float64 {
+		return value(n.p.hash3(i+c[0], j+c[1], k+c[2]))
+	})
+}
+
+// Eval4 returns the noise at x, y, z, w
+func (n *Value) Eval4(x, y, z, w float64) float64 {
+	i, fx := floor(x)
+	j, fy := floor(y)
+	k, fz := floor(z)
+	l, fw := floor(w)
+	return interpolate([4]float64{fade(fx), fade(fy), fade(fz), fade(fw)}, 4, func(c [4]int) float64 {
+		return value(n.p.hash4(i+c[0], j+c[1], k+c[2], l+c[3]))
+	})
+}
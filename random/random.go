@@ -0,0 +1,170 @@
+// Package random provides a seeded source of randomness for generative sketches. Everything is
+// derived from the seed, so a sketch can be plotted again from the seed alone.
+package random
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/circle"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+)
+
+// Rand is a seeded random number generator. It embeds rand.Rand so it can be passed to the
+// options of other packages as r.Rand.
+type Rand struct {
+	*rand.Rand
+	seed int64
+}
+
+// New creates a generator from the seed
+func New(seed int64) *Rand {
+	return &Rand{Rand: rand.New(rand.NewSource(seed)), seed: seed}
+}
+
+// Seed returns the seed the generator was created with. Unlike rand.Rand it cannot be reseeded.
+func (r *Rand) Seed() int64 {
+	return r.seed
+}
+
+// Stream returns a generator for one part of a sketch. Its seed depends only on this
+// generator's seed and the name, so the numbers it gives do not change when random calls are
+// added or removed elsewhere.
+func (r *Rand) Stream(name string) *Rand {
+	h := fnv.New64a()
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(r.seed >> (8 * i))
+	}
+	h.Write(b[:])
+	h.Write([]byte(name))
+	return New(int64(h.Sum64()))
+}
+
+// Range returns a number in [lo, hi)
+func (r *Rand) Range(lo, hi float64) float64 {
+	return lo + r.Float64()*(hi-lo)
+}
+
+// IntRange returns an integer in [lo, hi)
+func (r *Rand) IntRange(lo, hi int) int {
+	return lo + r.Intn(hi-lo)
+}
+
+// Gaussian returns a normally distributed number
+func (r *Rand) Gaussian(mean, stddev float64) float64 {
+	return mean + r.NormFloat64()*stddev
+}
+
+// Bool returns true with probability p
+func (r *Rand) Bool(p float64) bool {
+	return r.Float64() < p
+}
+
+// Angle returns an angle in [0, 2π)
+func (r *Rand) Angle() float64 {
+	return r.Float64() * 2 * math.Pi
+}
+
+// UnitVector returns a point at distance 1 from the origin in a uniformly random direction
+func (r *Rand) UnitVector() point.Point {
+	return point.NewPointFromAngle(r.Angle(), 1)
+}
+
+// InBounds returns a point spread uniformly over the bounds
+func (r *Rand) InBounds(b bounds.Bounds) point.Point {
+	return point.NewPoint(r.Range(b.Left, b.Right), r.Range(b.Top, b.Bottom))
+}
+
+// InCircle returns a point spread uniformly over the circle
+func (r *Rand) InCircle(c circle.Circle) point.Point {
+	return c.Center.AddPoint(point.NewPointFromAngle(r.Angle(), c.Radius*math.Sqrt(r.Float64())))
+}
+
+// InPolygon returns a point spread uniformly over the polygon. The polygon is triangulated
+// and a triangle is chosen by area, so it works for any shape without rejection sampling.
+// Triangulating is done on every call, so use PolygonSampler to draw many points from the same
+// polygon.
+func (r *Rand) InPolygon(p *polygon.Polygon) point.Point {
+	return r.PolygonSampler(p)()
+}
+
+// PolygonSampler returns a function that draws points spread uniformly over the polygon, as
+// InPolygon does. The polygon is triangulated once when the sampler is created.
+func (r *Rand) PolygonSampler(p *polygon.Polygon) func() point.Point {
+	triangles := p.Triangulate()
+	weights := make([]float64, len(triangles))
+	for i, t := range triangles {
+		weights[i] = t.Area()
+	}
+	return func() point.Point {
+		i := r.WeightedIndex(weights)
+		if i < 0 {
+			return p.Centroid()
+		}
+		pts := triangles[i].Points()
+		// Reflect points in the far half of the parallelogram back into the triangle
+		u, v := r.Float64(), r.Float64()
+		if u+v > 1 {
+			u, v = 1-u, 1-v
+		}
+		a, b, c := pts[0], pts[1], pts[2]
+		return a.AddPoint(b.SubtractPoint(a).ScalarMult(u)).AddPoint(c.SubtractPoint(a).ScalarMult(v))
+	}
+}
+
+// WeightedIndex returns an index chosen with probability proportional to its weight.
+// Negative weights count as zero. It returns -1 if no weight is positive.
+func (r *Rand) WeightedIndex(weights []float64) int {
+	total := 0.
+	for _, w := range weights {
+		total += math.Max(w, 0)
+	}
+	if total <= 0 {
+		return -1
+	}
+	x := r.Float64() * total
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if x < w {
+			return i
+		}
+		x -= w
+		last = i
+	}
+	// Rounding can leave x just past the end
+	return last
+}
+
+// Choice returns a random item. It panics if there are no items.
+func Choice[T any](r *Rand, items []T) T {
+	return items[r.Intn(len(items))]
+}
+
+// WeightedChoice returns an item chosen with probability proportional to its weight. Weights
+// past the end of the items are ignored. It panics if there are fewer weights than items or no
+// positive weights.
+func WeightedChoice[T any](r *Rand, items []T, weights []float64) T {
+	if len(weights) < len(items) {
+		panic(fmt.Sprintf("random: %d weights for %d items", len(weights), len(items)))
+	}
+	i := r.WeightedIndex(weights[:len(items)])
+	if i < 0 {
+		panic("random: no positive weights")
+	}
+	return items[i]
+}
+
+// Shuffle randomizes the order of the items in place
+func Shuffle[T any](r *Rand, items []T) {
+	r.Rand.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+}
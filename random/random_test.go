@@ -0,0 +1,110 @@
+package random
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/circle"
+	"github.com/srmullen/godraw-lib/geometry/d2/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeed(t *testing.T) {
+	a, b := New(7), New(7)
+	assert.Equal(t, int64(7), a.Seed())
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, a.Float64(), b.Float64())
+	}
+	assert.NotEqual(t, New(7).Float64(), New(8).Float64())
+}
+
+func TestStream(t *testing.T) {
+	r := New(3)
+	first := r.Stream("colors").Float64()
+	// Using the parent does not change its streams
+	r.Float64()
+	r.Intn(10)
+	assert.Equal(t, first, r.Stream("colors").Float64())
+	assert.Equal(t, first, New(3).Stream("colors").Float64())
+	assert.NotEqual(t, first, r.Stream("shapes").Float64())
+	assert.NotEqual(t, first, New(4).Stream("colors").Float64())
+}
+
+func TestHelpers(t *testing.T) {
+	r := New(1)
+	for i := 0; i < 1000; i++ {
+		v := r.Range(-2, 3)
+		assert.True(t, v >= -2 && v < 3)
+		n := r.IntRange(5, 8)
+		assert.True(t, n >= 5 && n < 8)
+		assert.InDelta(t, 1, r.UnitVector().Magnitude(), 1e-9)
+	}
+
+	sum := 0.
+	for i := 0; i < 10000; i++ {
+		sum += r.Gaussian(10, 2)
+	}
+	assert.InDelta(t, 10, sum/10000, 0.1)
+
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[WeightedChoice(r, []string{"a", "b", "c"}, []float64{1, 0, 3})]++
+	}
+	assert.Equal(t, 0, counts["b"])
+	assert.InDelta(t, 3, float64(counts["c"])/float64(counts["a"]), 0.3)
+	assert.Equal(t, -1, r.WeightedIndex([]float64{0, -1}))
+	assert.Equal(t, "a", WeightedChoice(r, []string{"a"}, []float64{1, 2}))
+	assert.PanicsWithValue(t, "random: 1 weights for 2 items", func() {
+		WeightedChoice(r, []string{"a", "b"}, []float64{1})
+	})
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	Shuffle(r, items)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, items)
+	assert.Contains(t, items, Choice(r, items))
+}
+
+func TestPoints(t *testing.T) {
+	r := New(2)
+	b := bounds.NewBounds(10, 30, 20, 0)
+	c := circle.NewCircle(5, 5, 2)
+	// An L shape whose bounds are mostly outside of it
+	l := polygon.NewPolygon(polygon.Points{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 10}, {X: 0, Y: 10}})
+	left := 0
+	for i := 0; i < 2000; i++ {
+		p := r.InBounds(b)
+		assert.True(t, b.Contains(p.X, p.Y))
+		p = r.InCircle(c)
+		assert.True(t, c.Contains(p.X, p.Y))
+		p = r.InPolygon(l)
+		assert.True(t, l.ContainsPoint(p.X, p.Y))
+		if p.X < 1 {
+			left++
+		}
+	}
+	// The arms have nearly equal area
+	assert.InDelta(t, 0.5, float64(left)/2000, 0.05)
+	assert.False(t, math.IsNaN(r.InPolygon(l).X))
+}
+
+func TestPolygonSampler(t *testing.T) {
+	l := polygon.NewPolygon(polygon.Points{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 10}, {X: 0, Y: 10}})
+	sample := New(3).PolygonSampler(l)
+	left := 0
+	for i := 0; i < 2000; i++ {
+		p := sample()
+		assert.True(t, l.ContainsPoint(p.X, p.Y))
+		if p.X < 1 {
+			left++
+		}
+	}
+	assert.InDelta(t, 0.5, float64(left)/2000, 0.05)
+
+	// The sampler draws the same points as InPolygon from the same seed
+	r := New(4)
+	sample = New(4).PolygonSampler(l)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, r.InPolygon(l), sample())
+	}
+}
@@ -0,0 +1,231 @@
+// Package text lays out strings in single stroke fonts, such as the Hershey fonts, so that each
+// line of a letter is drawn once by a plotter rather than traced around as an outline.
+package text
+
+import (
+	"strings"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/size"
+)
+
+// Defaults used by Options
+const (
+	DefaultSize       = 10.
+	DefaultLineHeight = 1.5
+)
+
+// A Glyph is the strokes that draw a character
+type Glyph struct {
+	// Advance is how far along the next glyph starts, in font units
+	Advance float64
+	// Strokes are lines in font units, with x from the start of the glyph and y pointing down
+	// from the baseline
+	Strokes [][]point.Point
+}
+
+// A Font is a set of single stroke glyphs
+type Font struct {
+	glyphs map[rune]Glyph
+	// capHeight is the height of capital letters in font units
+	capHeight float64
+}
+
+// NewFont creates a font from its glyphs. capHeight is the height of capital letters in the
+// units of the glyphs, which Options.Size scales to. Characters the font does not have are drawn
+// with its glyph for '?', or left blank if it has none.
+func NewFont(glyphs map[rune]Glyph, capHeight float64) *Font {
+	f := &Font{glyphs: make(map[rune]Glyph, len(glyphs)), capHeight: capHeight}
+	for r, g := range glyphs {
+		f.glyphs[r] = g
+	}
+	return f
+}
+
+// hersheyGlyphs reads glyphs for the printable ASCII characters in the format of simplexData
+func hersheyGlyphs(data [][]int) map[rune]Glyph {
+	ret := make(map[rune]Glyph, len(data))
+	for i, d := range data {
+		g := Glyph{Advance: float64(d[0])}
+		var stroke []point.Point
+		for j := 1; j+1 < len(d); j += 2 {
+			if d[j] == -1 && d[j+1] == -1 {
+				g.Strokes = append(g.Strokes, stroke)
+				stroke = nil
+				continue
+			}
+			stroke = append(stroke, point.NewPoint(float64(d[j]), -float64(d[j+1])))
+		}
+		if stroke != nil {
+			g.Strokes = append(g.Strokes, stroke)
+		}
+		ret[rune(' '+i)] = g
+	}
+	return ret
+}
+
+// Glyph returns the glyph for the character. ok is false if the font does not have it.
+func (f *Font) Glyph(r rune) (g Glyph, ok bool) {
+	g, ok = f.glyphs[r]
+	return g, ok
+}
+
+// glyph returns the glyph for the character, or a question mark if the font does not have it
+func (f *Font) glyph(r rune) Glyph {
+	if g, ok := f.glyphs[r]; ok {
+		return g
+	}
+	return f.glyphs['?']
+}
+
+// Align is how lines are placed relative to the x position of the text
+type Align int
+
+const (
+	// AlignLeft starts lines at x
+	AlignLeft Align = iota
+	// AlignCenter centers lines on x
+	AlignCenter
+	// AlignRight ends lines at x
+	AlignRight
+)
+
+// Options control how text is laid out.
+type Options struct {
+	// Font defaults to Simplex
+	Font *Font
+	// Size is the height of capital letters. A size without a unit is in drawing units, and one
+	// with a unit is converted to pixels, which are the units of the SVG. Defaults to
+	// DefaultSize drawing units.
+	Size size.Size
+	// Align places lines relative to x. Defaults to AlignLeft.
+	Align Align
+	// LetterSpacing is added between characters. It can be negative to tighten the text.
+	LetterSpacing float64
+	// LineHeight is the distance between baselines as a multiple of Size. Defaults to
+	// DefaultLineHeight.
+	LineHeight float64
+	// Width wraps lines at spaces so that they are no wider than it, unless a single word is
+	// wider. Zero does not wrap.
+	Width float64
+	// Rotation turns the text about x, y by this many radians, clockwise on screen
+	Rotation float64
+}
+
+func (o Options) font() *Font {
+	if o.Font == nil {
+		return Simplex
+	}
+	return o.Font
+}
+
+func (o Options) size() float64 {
+	if o.Size.Value <= 0 {
+		return DefaultSize
+	}
+	if o.Size.Unit == nil {
+		return o.Size.Value
+	}
+	// Going through inches avoids rounding to whole pixels
+	return o.Size.To(size.IN) * size.PX.Factor
+}
+
+func (o Options) lineHeight() float64 {
+	if o.LineHeight <= 0 {
+		return DefaultLineHeight
+	}
+	return o.LineHeight
+}
+
+// scale converts font units to drawing units
+func (o Options) scale() float64 {
+	return o.size() / o.font().capHeight
+}
+
+// width returns the width of a line
+func (o Options) width(line string) float64 {
+	f := o.font()
+	ret := 0.
+	n := 0
+	for _, r := range line {
+		ret += f.glyph(r).Advance * o.scale()
+		n++
+	}
+	if n > 1 {
+		ret += float64(n-1) * o.LetterSpacing
+	}
+	return ret
+}
+
+// lines splits the text at newlines and wraps it to the width
+func (o Options) lines(s string) []string {
+	var ret []string
+	for _, line := range strings.Split(s, "\n") {
+		if o.Width <= 0 {
+			ret = append(ret, line)
+			continue
+		}
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			ret = append(ret, "")
+			continue
+		}
+		current := words[0]
+		for _, word := range words[1:] {
+			if next := current + " " + word; o.width(next) <= o.Width {
+				current = next
+			} else {
+				ret = append(ret, current)
+				current = word
+			}
+		}
+		ret = append(ret, current)
+	}
+	return ret
+}
+
+// Lines returns the lines the text is split into, after wrapping to the width
+func Lines(s string, opts Options) []string {
+	return opts.lines(s)
+}
+
+// Width returns the width of the widest line of the text
+func Width(s string, opts Options) float64 {
+	ret := 0.
+	for _, line := range opts.lines(s) {
+		ret = max(ret, opts.width(line))
+	}
+	return ret
+}
+
+// Paths returns the strokes that draw the text, with the baseline of the first line at y. Each
+// stroke is an open path. Characters the font does not have are drawn as question marks.
+func Paths(x, y float64, s string, opts Options) []*path.Path {
+	f := opts.font()
+	scale := opts.scale()
+	origin := point.NewPoint(x, y)
+	var ret []*path.Path
+	for i, line := range opts.lines(s) {
+		cursor := point.NewPoint(0, float64(i)*opts.lineHeight()*opts.size())
+		switch opts.Align {
+		case AlignCenter:
+			cursor.X -= opts.width(line) / 2
+		case AlignRight:
+			cursor.X -= opts.width(line)
+		}
+		for _, r := range line {
+			g := f.glyph(r)
+			for _, stroke := range g.Strokes {
+				coords := make([]float64, 0, 2*len(stroke))
+				for _, p := range stroke {
+					q := cursor.AddPoint(p.ScalarMult(scale)).Rotate(opts.Rotation).AddPoint(origin)
+					coords = append(coords, q.X, q.Y)
+				}
+				ret = append(ret, path.NewOpenPath(coords))
+			}
+			cursor.X += g.Advance*scale + opts.LetterSpacing
+		}
+	}
+	return ret
+}
@@ -0,0 +1,138 @@
+package text
+
+import (
+	"math"
+	"testing"
+
+	"github.com/srmullen/godraw-lib/geometry/d2/bounds"
+	"github.com/srmullen/godraw-lib/geometry/d2/path"
+	"github.com/srmullen/godraw-lib/geometry/d2/point"
+	"github.com/srmullen/godraw-lib/size"
+	"github.com/stretchr/testify/assert"
+)
+
+// extent returns the bounds of the points of the paths
+func extent(paths []*path.Path) bounds.Bounds {
+	ret := bounds.NewBounds(math.Inf(1), math.Inf(-1), math.Inf(-1), math.Inf(1))
+	for _, p := range paths {
+		for _, pt := range p.Points() {
+			ret.Top = math.Min(ret.Top, pt.Y)
+			ret.Right = math.Max(ret.Right, pt.X)
+			ret.Bottom = math.Max(ret.Bottom, pt.Y)
+			ret.Left = math.Min(ret.Left, pt.X)
+		}
+	}
+	return ret
+}
+
+func TestSimplex(t *testing.T) {
+	for r := ' '; r <= '~'; r++ {
+		g, ok := Simplex.Glyph(r)
+		assert.True(t, ok, string(r))
+		assert.Greater(t, g.Advance, 0.)
+		for _, stroke := range g.Strokes {
+			assert.NotEmpty(t, stroke)
+		}
+	}
+	_, ok := Simplex.Glyph('é')
+	assert.False(t, ok)
+
+	g, _ := Simplex.Glyph('H')
+	assert.Len(t, g.Strokes, 3)
+	assert.Equal(t, []point.Point{{X: 4, Y: -21}, {X: 4, Y: 0}}, g.Strokes[0])
+}
+
+func TestNewFont(t *testing.T) {
+	// A font of a single square glyph 10 units tall
+	box := Glyph{Advance: 12, Strokes: [][]point.Point{{{X: 1, Y: 0}, {X: 1, Y: -10}, {X: 11, Y: -10}, {X: 11, Y: 0}, {X: 1, Y: 0}}}}
+	glyphs := map[rune]Glyph{'#': box}
+	f := NewFont(glyphs, 10)
+	delete(glyphs, '#')
+	g, ok := f.Glyph('#')
+	assert.True(t, ok)
+	assert.Equal(t, box, g)
+
+	opts := Options{Font: f, Size: size.Size{Value: 20}}
+	assert.Equal(t, 48., Width("##", opts))
+	paths := Paths(0, 0, "##", opts)
+	assert.Len(t, paths, 2)
+	b := extent(paths)
+	assert.Equal(t, -20., b.Top)
+	assert.Equal(t, 0., b.Bottom)
+	assert.Equal(t, 2., b.Left)
+	assert.Equal(t, 46., b.Right)
+
+	// Without a question mark missing characters are blank
+	assert.Empty(t, Paths(0, 0, "a", opts))
+	assert.Equal(t, 0., Width("a", opts))
+}
+
+func TestPaths(t *testing.T) {
+	// Capital letters sit on the baseline and are Size tall
+	paths := Paths(100, 50, "H", Options{Size: size.Size{Value: 21}})
+	assert.Len(t, paths, 3)
+	b := extent(paths)
+	assert.Equal(t, 29., b.Top)
+	assert.Equal(t, 50., b.Bottom)
+	assert.Equal(t, 104., b.Left)
+	assert.Equal(t, 118., b.Right)
+
+	// Sizes scale from the origin of the text
+	b = extent(Paths(100, 50, "H", Options{Size: size.Size{Value: 42}}))
+	assert.Equal(t, 8., b.Top)
+	assert.Equal(t, 108., b.Left)
+
+	// Physical sizes are converted to pixels
+	b = extent(Paths(0, 0, "H", Options{Size: size.Size{Unit: size.MM, Value: 10}}))
+	assert.InDelta(t, -10/25.4*96, b.Top, 1e-9)
+	b = extent(Paths(0, 0, "H", Options{Size: size.Size{Unit: size.PT, Value: 72}}))
+	assert.InDelta(t, -96, b.Top, 1e-9)
+
+	assert.Empty(t, Paths(0, 0, " ", Options{}))
+	// Missing characters are question marks
+	assert.Equal(t, Paths(0, 0, "?", Options{}), Paths(0, 0, "é", Options{}))
+}
+
+func TestAlign(t *testing.T) {
+	opts := Options{Size: size.Size{Value: 21}}
+	w := Width("HI", opts)
+	assert.Equal(t, 30., w)
+	opts.LetterSpacing = 2
+	assert.Equal(t, 32., Width("HI", opts))
+
+	left := extent(Paths(0, 0, "HI", opts))
+	opts.Align = AlignCenter
+	center := extent(Paths(0, 0, "HI", opts))
+	opts.Align = AlignRight
+	right := extent(Paths(0, 0, "HI", opts))
+	assert.InDelta(t, left.Left-16, center.Left, 1e-9)
+	assert.InDelta(t, left.Left-32, right.Left, 1e-9)
+}
+
+func TestLines(t *testing.T) {
+	opts := Options{Size: size.Size{Value: 10}}
+	assert.Equal(t, []string{"one", "two"}, Lines("one\ntwo", opts))
+	b := extent(Paths(0, 0, "I\nI", opts))
+	assert.Equal(t, -10., b.Top)
+	assert.Equal(t, 15., b.Bottom)
+
+	opts.LineHeight = 2
+	assert.Equal(t, 20., extent(Paths(0, 0, "I\nI", opts)).Bottom)
+
+	// Wrapping breaks at spaces but never inside a word
+	opts.Width = Width("the quick\nbrown fox", opts)
+	assert.Equal(t, []string{"the quick", "brown fox", "jumps", ""}, Lines("the quick brown fox jumps\n", opts))
+	opts.Width = 1
+	assert.Equal(t, []string{"the", "quick"}, Lines("the quick", opts))
+	assert.LessOrEqual(t, Width("the quick brown fox jumps", Options{Size: size.Size{Value: 10}, Width: 100}), 100.)
+}
+
+func TestRotation(t *testing.T) {
+	// A quarter turn clockwise stands the I on its side, pointing right from the baseline
+	paths := Paths(10, 10, "I", Options{Size: size.Size{Value: 21}, Rotation: math.Pi / 2})
+	pts := paths[0].Points()
+	assert.InDelta(t, 31, pts[0].X, 1e-9)
+	assert.InDelta(t, 14, pts[0].Y, 1e-9)
+	assert.InDelta(t, 10, pts[1].X, 1e-9)
+	assert.InDelta(t, 14, pts[1].Y, 1e-9)
+}